@@ -1,20 +1,29 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/config"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/repos"
+	_ "github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/schema" // registers the schema migrations
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/scraper"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/distributions"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/funds"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/holding"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/overview"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/performance"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/validation"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/calendar"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/lookup"
 )
 
 func main() {
+	ctx := context.Background()
 	appConf := config.AppConf
 
 	// create new logger
@@ -24,6 +33,14 @@ func main() {
 	}
 	defer zap.Close()
 
+	// resolve scraped country names with the configured aliases and fuzzy matching
+	lookup.SetCountryMatchThreshold(appConf.Lookup.CountryMatchThreshold)
+	if appConf.Lookup.CountryAliasFile != "" {
+		if err := lookup.LoadCountryAliases(appConf.Lookup.CountryAliasFile); err != nil {
+			log.Fatalf("load country aliases failed: %v", err)
+		}
+	}
+
 	// create new repository
 	repo, err := repos.NewFundMongo(nil, zap, &appConf.Mongo)
 	if err != nil {
@@ -31,15 +48,37 @@ func main() {
 	}
 	defer repo.Close()
 
+	// migrate stored documents before the scrape writes with the current schema
+	if err := migrations.NewService(repo, zap).EnsureSchema(ctx, appConf.Mongo.SchemaVersion); err != nil {
+		log.Fatalf("ensure schema failed: %v", err)
+	}
+
 	// create new service
-	fundService := funds.NewService(repo, zap)
-	fundHoldingService := holding.NewService(repo, zap)
-	fundOverviewService := overview.NewService(repo, zap)
-	fundDistributionService := distributions.NewService(repo, zap)
-
-	// create new scraper jobs
-	jobs := scraper.NewFundScraper(fundService, fundHoldingService, fundOverviewService, fundDistributionService, zap)
-	jobs.ScrapeAllVanguardFundsDetails()
+	validationService, err := validation.NewService(&appConf.Validation, zap)
+	if err != nil {
+		log.Fatal("create validation service failed")
+	}
+
+	fundService := funds.NewService(repo, validationService, zap)
+	fundHoldingService := holding.NewService(repo, validationService, zap)
+	fundOverviewService := overview.NewService(repo, validationService, zap)
+	fundDistributionService := distributions.NewService(repo, validationService, zap)
+	fundPerformanceService := performance.NewService(repo, validationService, zap)
+
+	// Vanguard does not publish new data when the TSX is closed
+	today := datetime.Today()
+	if calendar.IsBusinessDay(today) {
+		// create new scraper jobs
+		jobs := scraper.NewFundScraper(fundService, fundHoldingService, fundOverviewService, fundDistributionService, fundPerformanceService, zap)
+		jobs.ScrapeAllVanguardFundsDetails()
+
+		// list the country names to add aliases for
+		for _, country := range fundOverviewService.GetUnresolvedCountries() {
+			zap.Warn(ctx, "unresolved country", "country", country.CountryName, "weight", country.Weight.String(), "tickers", country.Tickers)
+		}
+	} else {
+		zap.Info(ctx, "skip scraping, TSX is closed", "date", today.Format(datetime.DATE_LAYOUT))
+	}
 
 	lambda.Start(lambdaHandler)
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/config"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/repos"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/portfolio"
+)
+
+// portfolioRequest struct is the body of the api request
+type portfolioRequest struct {
+	Positions []*entities.PortfolioPosition `json:"positions,omitempty"`
+}
+
+func main() {
+	appConf := config.AppConf
+
+	// create new logger
+	zap, err := logger.NewZapLogger()
+	if err != nil {
+		log.Fatal("create app logger failed")
+	}
+	defer zap.Close()
+
+	// create new repository
+	repo, err := repos.NewFundMongo(nil, zap, &appConf.Mongo)
+	if err != nil {
+		log.Fatal("create fund mongo repo failed")
+	}
+	defer repo.Close()
+
+	// create new service
+	portfolioService := portfolio.NewService(repo, zap)
+
+	lambda.Start(newLambdaHandler(portfolioService, zap))
+}
+
+// newLambdaHandler creates api gateway handler computing look-through exposure of a portfolio
+func newLambdaHandler(portfolioService *portfolio.Service, log logger.ContextLog) func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		var body portfolioRequest
+		if err := json.Unmarshal([]byte(req.Body), &body); err != nil {
+			log.Warn(ctx, "unmarshal portfolio request failed", "error", err)
+			return newResponse(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		}
+
+		exposure, err := portfolioService.GetPortfolioExposure(ctx, body.Positions)
+		if errors.Is(err, portfolio.ErrInvalidPortfolio) {
+			return newResponse(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		if err != nil {
+			log.Error(ctx, "get portfolio exposure failed", "error", err)
+			return newResponse(http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		}
+
+		return newResponse(http.StatusOK, exposure)
+	}
+}
+
+// newResponse creates api gateway json response
+func newResponse(statusCode int, body interface{}) (events.APIGatewayProxyResponse, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(data),
+	}, nil
+}
@@ -1,21 +1,156 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/config"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/exporter"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/repos"
+	_ "github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/schema" // registers the schema migrations
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/scraper"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/analytics"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/bonds"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/changes"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/distributions"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/dividends"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/export"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/funds"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/holding"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/overlap"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/overview"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/performance"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/portfolio"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/quarantine"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/sectors"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/symbology"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/tax"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/topholdings"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/validation"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/lookup"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/taxonomy"
 )
 
+// command is a sub command of the command line
+type command struct {
+	usage string
+	run   func(ctx context.Context, app *app, args []string) error
+}
+
+// commands lists all supported sub commands, scrape is the default one
+var commands = map[string]command{
+	"scrape": {
+		usage: "scrape all Vanguard funds details [-parquet-dir dir]",
+		run:   runScrape,
+	},
+	"export-parquet": {
+		usage: "export stored funds as partitioned parquet files -out dir",
+		run:   runExportParquet,
+	},
+	"export-xlsx": {
+		usage: "export stored funds as an excel workbook -out file.xlsx",
+		run:   runExportExcel,
+	},
+	"portfolio": {
+		usage: "compute look-through exposure of a portfolio -positions VCN:40,VUN:30,VAB:30",
+		run:   runPortfolio,
+	},
+	"overlap": {
+		usage: "compute holding overlap between funds -tickers VFV,VUN [-top 10]",
+		run:   runOverlap,
+	},
+	"top-holdings": {
+		usage: "print the largest stock holdings of a fund -ticker VFV [-n 10]",
+		run:   runTopHoldings,
+	},
+	"dividend-calendar": {
+		usage: "list past and upcoming distributions [-as-of date] [-from date] [-to date] [-ics file.ics]",
+		run:   runDividendCalendar,
+	},
+	"distribution-analytics": {
+		usage: "compute trailing yield and distribution analytics [-tickers VFV,VAB] [-as-of date]",
+		run:   runDistributionAnalytics,
+	},
+	"bond-analytics": {
+		usage: "aggregate bond holdings into coupon and issuer type buckets [-tickers VAB,VSB]",
+		run:   runBondAnalytics,
+	},
+	"performance": {
+		usage: "print trailing NAV, market price and benchmark returns [-tickers VFV,VAB]",
+		run:   runPerformance,
+	},
+	"tax-summary": {
+		usage: "approximate the T3 tax character of distributions -year 2021 [-tickers VFV,VAB]",
+		run:   runTaxSummary,
+	},
+	"changes": {
+		usage: "print changes detected between scrapes [-since date] [-tickers VFV,VAB] [-types MER_FEE_CHANGED,NEW_DISTRIBUTION]",
+		run:   runChanges,
+	},
+	"migrate": {
+		usage: "upgrade or downgrade stored documents to a schema version [-to 2] [-dry-run] [-ledger]",
+		run:   runMigrate,
+	},
+	"quarantine-list": {
+		usage: "list documents quarantined by validation [-status PENDING|APPROVED|DISCARDED|ALL]",
+		run:   runQuarantineList,
+	},
+	"quarantine-inspect": {
+		usage: "print a quarantined document with its raw entity and failed rules -id id",
+		run:   runQuarantineInspect,
+	},
+	"quarantine-approve": {
+		usage: "promote a quarantined document to the live collection -id id [-force]",
+		run:   runQuarantineApprove,
+	},
+	"quarantine-discard": {
+		usage: "discard a quarantined document and keep the stored one -id id",
+		run:   runQuarantineDiscard,
+	},
+	"symbology": {
+		usage: "print the tickers of a fund in every vendor format -symbol VFV.TO|TSE:VFV|VFV CN|isin|sedol",
+		run:   runSymbology,
+	},
+	"sectors": {
+		usage: "print the sector breakdown of funds under a sector taxonomy version [-tickers VFV,VAB] [-taxonomy GICS_2016|GICS_2018|GICS_2023]",
+		run:   runSectors,
+	},
+}
+
+// app struct holds dependencies shared by sub commands
+type app struct {
+	repo *repos.FundMongo
+	log  logger.ContextLog
+}
+
 func main() {
 	appConf := config.AppConf
 
+	// which sub command we are going to run
+	name := "scrape"
+	args := os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		printUsage()
+		os.Exit(2)
+	}
+
 	// create new logger
 	zap, err := logger.NewZapLogger()
 	if err != nil {
@@ -23,6 +158,14 @@ func main() {
 	}
 	defer zap.Close()
 
+	// resolve scraped country names with the configured aliases and fuzzy matching
+	lookup.SetCountryMatchThreshold(appConf.Lookup.CountryMatchThreshold)
+	if appConf.Lookup.CountryAliasFile != "" {
+		if err := lookup.LoadCountryAliases(appConf.Lookup.CountryAliasFile); err != nil {
+			log.Fatalf("load country aliases failed: %v", err)
+		}
+	}
+
 	// create new repository
 	repo, err := repos.NewFundMongo(nil, zap, &appConf.Mongo)
 	if err != nil {
@@ -30,14 +173,546 @@ func main() {
 	}
 	defer repo.Close()
 
+	if err := cmd.run(context.Background(), &app{repo: repo, log: zap}, args); err != nil {
+		log.Fatalf("%s failed: %v", name, err)
+	}
+}
+
+// printUsage prints usage of all sub commands
+func printUsage() {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", name, commands[name].usage)
+	}
+}
+
+///////////////////////////////////////////////////////////
+// Sub commands
+///////////////////////////////////////////////////////////
+
+// runScrape scrapes all Vanguard funds details and optionally exports them afterward
+func runScrape(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("scrape", flag.ExitOnError)
+	parquetDir := fs.String("parquet-dir", "", "export parquet files to this directory after scraping")
+	validationReport := fs.String("validation-report", "", "write per-fund validation results as json to this file after scraping")
+	unresolvedReport := fs.String("unresolved-report", "", "write country names no country is resolved from with their total weight as json to this file after scraping")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// migrate stored documents before the scrape writes with the current schema
+	if err := migrations.NewService(a.repo, a.log).EnsureSchema(ctx, config.AppConf.Mongo.SchemaVersion); err != nil {
+		return err
+	}
+
 	// create new service
-	fundService := funds.NewService(repo, zap)
-	fundHoldingService := holding.NewService(repo, zap)
-	fundOverviewService := overview.NewService(repo, zap)
-	fundDistributionService := distributions.NewService(repo, zap)
+	validationService, err := validation.NewService(&config.AppConf.Validation, a.log)
+	if err != nil {
+		return err
+	}
+
+	fundService := funds.NewService(a.repo, validationService, a.log)
+	fundHoldingService := holding.NewService(a.repo, validationService, a.log)
+	fundOverviewService := overview.NewService(a.repo, validationService, a.log)
+	fundDistributionService := distributions.NewService(a.repo, validationService, a.log)
+	fundPerformanceService := performance.NewService(a.repo, validationService, a.log)
 
 	// create new scraper jobs
-	jobs := scraper.NewFundScraper(fundService, fundHoldingService, fundOverviewService, fundDistributionService, zap)
+	jobs := scraper.NewFundScraper(fundService, fundHoldingService, fundOverviewService, fundDistributionService, fundPerformanceService, a.log)
 	jobs.ScrapeAllVanguardFundsDetails()
 	// jobs.ScrapeSingleFundsOverview("9559", "Debugging")
+
+	if *validationReport != "" {
+		if err := writeJSON(*validationReport, validationService.GetResults()); err != nil {
+			return err
+		}
+	}
+
+	if *unresolvedReport != "" {
+		if err := writeJSON(*unresolvedReport, fundOverviewService.GetUnresolvedCountries()); err != nil {
+			return err
+		}
+	}
+
+	if *parquetDir != "" {
+		return exportParquet(ctx, a, *parquetDir)
+	}
+
+	return nil
+}
+
+// runExportParquet exports stored funds as parquet files
+func runExportParquet(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("export-parquet", flag.ExitOnError)
+	outDir := fs.String("out", "", "output directory of the parquet files")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *outDir == "" {
+		return fmt.Errorf("missing -out flag")
+	}
+
+	return exportParquet(ctx, a, *outDir)
+}
+
+func exportParquet(ctx context.Context, a *app, outDir string) error {
+	exportService := export.NewService(a.repo, a.log)
+	return exporter.NewParquetExporter(exportService, outDir, a.log).Export(ctx)
+}
+
+// runExportExcel exports stored funds as an excel workbook
+func runExportExcel(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("export-xlsx", flag.ExitOnError)
+	outFile := fs.String("out", "", "output excel file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *outFile == "" {
+		return fmt.Errorf("missing -out flag")
+	}
+
+	exportService := export.NewService(a.repo, a.log)
+	return exporter.NewExcelExporter(exportService, *outFile, a.log).Export(ctx)
+}
+
+// runPortfolio prints look-through exposure of a portfolio as json
+func runPortfolio(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("portfolio", flag.ExitOnError)
+	rawPositions := fs.String("positions", "", "comma separated ticker:weight pairs, e.g. VCN:40,VUN:30,VAB:30")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	positions, err := parsePositions(*rawPositions)
+	if err != nil {
+		return err
+	}
+
+	portfolioService := portfolio.NewService(a.repo, a.log)
+	exposure, err := portfolioService.GetPortfolioExposure(ctx, positions)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(exposure)
+}
+
+// runOverlap prints holding overlap between funds as json
+func runOverlap(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("overlap", flag.ExitOnError)
+	tickers := fs.String("tickers", "", "comma separated tickers, e.g. VFV,VUN")
+	top := fs.Int("top", 10, "number of top overlapping names")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	overlapService := overlap.NewService(a.repo, a.log)
+	fundOverlap, err := overlapService.GetFundOverlap(ctx, splitList(*tickers), *top)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(fundOverlap)
+}
+
+// runTopHoldings prints the largest stock holdings of a fund as json
+func runTopHoldings(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("top-holdings", flag.ExitOnError)
+	fundTicker := fs.String("ticker", "", "ticker of the fund")
+	n := fs.Int("n", 10, "number of holdings")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *fundTicker == "" {
+		return fmt.Errorf("-ticker is required")
+	}
+
+	topHoldingService := topholdings.NewService(a.repo, a.log)
+	topHoldings, err := topHoldingService.GetTopHoldings(ctx, *fundTicker, *n)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(topHoldings)
+}
+
+// runDividendCalendar prints past and upcoming distributions as json or writes them as an iCalendar feed
+func runDividendCalendar(ctx context.Context, a *app, args []string) error {
+	today := datetime.Today().Format(datetime.DATE_LAYOUT)
+
+	fs := flag.NewFlagSet("dividend-calendar", flag.ExitOnError)
+	rawAsOf := fs.String("as-of", today, "distributions from this date (YYYY-MM-DD) are upcoming")
+	rawFrom := fs.String("from", "", "first ex-dividend date (YYYY-MM-DD), default one year before -as-of")
+	rawTo := fs.String("to", "", "last ex-dividend date (YYYY-MM-DD), default six months after -as-of")
+	icsFile := fs.String("ics", "", "write an iCalendar feed to this file instead of printing json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	asOf, err := time.Parse(datetime.DATE_LAYOUT, *rawAsOf)
+	if err != nil {
+		return fmt.Errorf("invalid -as-of: %v", err)
+	}
+
+	from, to := asOf.AddDate(-1, 0, 0), asOf.AddDate(0, 6, 0)
+	if *rawFrom != "" {
+		if from, err = time.Parse(datetime.DATE_LAYOUT, *rawFrom); err != nil {
+			return fmt.Errorf("invalid -from: %v", err)
+		}
+	}
+	if *rawTo != "" {
+		if to, err = time.Parse(datetime.DATE_LAYOUT, *rawTo); err != nil {
+			return fmt.Errorf("invalid -to: %v", err)
+		}
+	}
+
+	dividendService := dividends.NewService(a.repo, a.log)
+
+	if *icsFile != "" {
+		return exporter.NewICSExporter(dividendService, *icsFile, a.log).Export(ctx, asOf, from, to)
+	}
+
+	calendar, err := dividendService.GetDividendCalendar(ctx, asOf, from, to)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(calendar)
+}
+
+// runDistributionAnalytics prints distribution analytics of funds as json
+func runDistributionAnalytics(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("distribution-analytics", flag.ExitOnError)
+	tickers := fs.String("tickers", "", "comma separated tickers, all funds if empty")
+	rawAsOf := fs.String("as-of", datetime.Today().Format(datetime.DATE_LAYOUT), "compute analytics as of this date (YYYY-MM-DD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	asOf, err := time.Parse(datetime.DATE_LAYOUT, *rawAsOf)
+	if err != nil {
+		return fmt.Errorf("invalid -as-of: %v", err)
+	}
+
+	analyticsService := analytics.NewService(a.repo, a.log)
+	results, err := analyticsService.GetDistributionAnalytics(ctx, splitList(*tickers), asOf)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(results)
+}
+
+// runBondAnalytics prints bond analytics of bond and balanced funds as json
+func runBondAnalytics(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("bond-analytics", flag.ExitOnError)
+	tickers := fs.String("tickers", "", "comma separated tickers, all bond and balanced funds if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	bondService := bonds.NewService(a.repo, a.log)
+	results, err := bondService.GetBondAnalytics(ctx, splitList(*tickers))
+	if err != nil {
+		return err
+	}
+
+	return printJSON(results)
+}
+
+// runPerformance prints the trailing returns of funds as json
+func runPerformance(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("performance", flag.ExitOnError)
+	tickers := fs.String("tickers", "", "comma separated tickers, all funds if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// returns are only read, nothing is validated
+	performanceService := performance.NewService(a.repo, nil, a.log)
+	results, err := performanceService.GetFundReturns(ctx, splitList(*tickers))
+	if err != nil {
+		return err
+	}
+
+	return printJSON(results)
+}
+
+// runTaxSummary prints the approximate T3 boxes of funds for a tax year as json
+func runTaxSummary(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("tax-summary", flag.ExitOnError)
+	year := fs.Int("year", datetime.Today().Year()-1, "tax year")
+	tickers := fs.String("tickers", "", "comma separated tickers, all funds if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	taxService := tax.NewService(a.repo, a.log)
+	summaries, err := taxService.GetTaxSummaries(ctx, splitList(*tickers), *year)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(summaries)
+}
+
+// runChanges prints changes detected between consecutive scrapes as json
+func runChanges(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("changes", flag.ExitOnError)
+	rawSince := fs.String("since", datetime.Today().AddDate(0, 0, -7).Format(datetime.DATE_LAYOUT), "changes detected from this date (YYYY-MM-DD)")
+	tickers := fs.String("tickers", "", "comma separated tickers, all funds if empty")
+	types := fs.String("types", "", "comma separated change types, all types if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	since, err := time.Parse(datetime.DATE_LAYOUT, *rawSince)
+	if err != nil {
+		return fmt.Errorf("invalid -since: %v", err)
+	}
+
+	changeService := changes.NewService(a.repo, a.log)
+	fundChanges, err := changeService.GetFundChanges(ctx, since, splitList(*tickers), splitList(*types))
+	if err != nil {
+		return err
+	}
+
+	return printJSON(fundChanges)
+}
+
+// runMigrate migrates stored documents to a schema version and prints what was migrated as json
+func runMigrate(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	to := fs.String("to", config.AppConf.Mongo.SchemaVersion, "target schema version, default the configured one")
+	dryRun := fs.Bool("dry-run", false, "report what would be migrated without writing anything")
+	ledger := fs.Bool("ledger", false, "print the migrations-applied ledger instead of migrating")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	migrationService := migrations.NewService(a.repo, a.log)
+
+	if *ledger {
+		results, err := migrationService.GetAppliedMigrations(ctx)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(results)
+	}
+
+	results, err := migrationService.Migrate(ctx, *to, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(results)
+}
+
+// runQuarantineList prints quarantined documents without their raw entity as json
+func runQuarantineList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("quarantine-list", flag.ExitOnError)
+	status := fs.String("status", entities.QUARANTINE_STATUS_PENDING, "PENDING, APPROVED, DISCARDED or ALL")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(*status) {
+	case entities.QUARANTINE_STATUS_PENDING, entities.QUARANTINE_STATUS_APPROVED, entities.QUARANTINE_STATUS_DISCARDED:
+		*status = strings.ToUpper(*status)
+	case "ALL":
+		*status = ""
+	default:
+		return fmt.Errorf("invalid -status %q", *status)
+	}
+
+	quarantineService := quarantine.NewService(a.repo, a.log)
+	items, err := quarantineService.ListQuarantinedItems(ctx, *status)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(items)
+}
+
+// runQuarantineInspect prints a quarantined document as json
+func runQuarantineInspect(ctx context.Context, a *app, args []string) error {
+	id, err := parseQuarantineID("quarantine-inspect", args)
+	if err != nil {
+		return err
+	}
+
+	quarantineService := quarantine.NewService(a.repo, a.log)
+	item, err := quarantineService.GetQuarantinedItem(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(item)
+}
+
+// runQuarantineApprove promotes a quarantined document and prints it as json
+func runQuarantineApprove(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("quarantine-approve", flag.ExitOnError)
+	id := fs.String("id", "", "id of the quarantined document, see quarantine-list")
+	force := fs.Bool("force", false, "approve even if the live document is newer")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *id == "" {
+		return fmt.Errorf("-id is required")
+	}
+
+	quarantineService := quarantine.NewService(a.repo, a.log)
+	item, err := quarantineService.ApproveQuarantinedItem(ctx, *id, *force)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(item)
+}
+
+// runQuarantineDiscard discards a quarantined document and prints it as json
+func runQuarantineDiscard(ctx context.Context, a *app, args []string) error {
+	id, err := parseQuarantineID("quarantine-discard", args)
+	if err != nil {
+		return err
+	}
+
+	quarantineService := quarantine.NewService(a.repo, a.log)
+	item, err := quarantineService.DiscardQuarantinedItem(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(item)
+}
+
+// runSymbology prints the symbology of a fund as json
+func runSymbology(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("symbology", flag.ExitOnError)
+	symbol := fs.String("symbol", "", "ticker of any vendor format, ISIN or SEDOL")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *symbol == "" {
+		return fmt.Errorf("-symbol is required")
+	}
+
+	symbologyService := symbology.NewService(a.repo, a.log)
+	fundSymbology, err := symbologyService.GetSymbology(ctx, *symbol)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(fundSymbology)
+}
+
+// runSectors prints the sector breakdown of funds remapped to a sector taxonomy version as json
+func runSectors(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("sectors", flag.ExitOnError)
+	tickers := fs.String("tickers", "", "comma separated tickers, all funds if empty")
+	version := fs.String("taxonomy", taxonomy.CurrentVersion(), "sector taxonomy version")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sectorService := sectors.NewService(a.repo, a.log)
+	results, err := sectorService.GetFundSectors(ctx, splitList(*tickers), *version)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(results)
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// parseQuarantineID parses the required -id flag of the quarantine commands
+func parseQuarantineID(name string, args []string) (string, error) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	id := fs.String("id", "", "id of the quarantined document, see quarantine-list")
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+
+	if *id == "" {
+		return "", fmt.Errorf("-id is required")
+	}
+
+	return *id, nil
+}
+
+// parsePositions parses comma separated ticker:weight pairs
+func parsePositions(rawPositions string) ([]*entities.PortfolioPosition, error) {
+	var positions []*entities.PortfolioPosition
+
+	for _, pair := range strings.Split(rawPositions, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid position %q, expected ticker:weight", pair)
+		}
+
+		weight, err := decimal.NewFromString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight of position %q: %v", pair, err)
+		}
+
+		positions = append(positions, &entities.PortfolioPosition{
+			Ticker: strings.TrimSpace(parts[0]),
+			Weight: weight,
+		})
+	}
+
+	return positions, nil
+}
+
+// printJSON prints a value as indented json to stdout
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeJSON writes indented json to a file
+func writeJSON(file string, v interface{}) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// splitList splits a comma separated list and drops empty items
+func splitList(list string) []string {
+	var items []string
+
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
 }
@@ -38,3 +38,11 @@ func GetFundHoldingURL(portID, issueType, assetCode string) string {
 
 	return URL
 }
+
+// ScrapeFundPerformance const, the performance response keys are not verified against a captured response yet
+const ScrapeFundPerformance = false
+
+// GetFundPerformanceURL get fund performance url
+func GetFundPerformanceURL(portID, issueType string) string {
+	return fmt.Sprintf("https://api.vanguard.com/rs/gre/gra/1.7.0/datasets/caw-indv-performance.json?vars=portId:%s,issueType:%s&path=[portId=%s][0]", portID, issueType, portID)
+}
@@ -2,19 +2,37 @@ package config
 
 // MongoConfig struct
 type MongoConfig struct {
-	TimeoutMS     uint64
-	MinPoolSize   uint64
-	MaxPoolSize   uint64
-	MaxIdleTimeMS uint64
-	SchemaVersion string
-	Username      string
-	Password      string
-	Host          string
-	Dbname        string
-	Colnames      map[string]string
+	ConnectTimeoutMS uint64 // connect to and disconnect from the cluster
+	ReadTimeoutMS    uint64 // find documents
+	WriteTimeoutMS   uint64 // upsert or insert documents
+	BulkTimeoutMS    uint64 // update many documents, or migrate whole collections
+	MinPoolSize      uint64
+	MaxPoolSize      uint64
+	MaxIdleTimeMS    uint64
+	SchemaVersion    string
+	Username         string
+	Password         string
+	Host             string
+	Dbname           string
+	Colnames         map[string]string
+}
+
+// ValidationConfig struct is the policy applied to scraped documents failing validation
+type ValidationConfig struct {
+	OnError            string  // STORE, QUARANTINE or REJECT a document with errors
+	OnWarning          string  // STORE, QUARANTINE or REJECT a document with warnings only
+	WeightSumTolerance float64 // accepted distance of sector, country and allocation weights from 100 percent
+}
+
+// LookupConfig struct is how scraped country names are resolved to countries
+type LookupConfig struct {
+	CountryAliasFile      string  // json file of extra country names keyed by alpha-3 code, none if empty
+	CountryMatchThreshold float64 // lowest similarity from 0 to 1 a misspelled country name is fuzzy matched at
 }
 
 // AppConfig struct
 type AppConfig struct {
-	Mongo MongoConfig
+	Mongo      MongoConfig
+	Validation ValidationConfig
+	Lookup     LookupConfig
 }
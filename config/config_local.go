@@ -5,20 +5,36 @@ package config
 // AppConf constants
 var AppConf = AppConfig{
 	Mongo: MongoConfig{
-		TimeoutMS:     360000,
-		MinPoolSize:   5,
-		MaxPoolSize:   10,
-		MaxIdleTimeMS: 360000,
-		Host:          "lenoobdev.l8ckp.mongodb.net",
-		Username:      "lenoob_dev",
-		Password:      "lenoob_dev",
-		Dbname:        "povi",
-		SchemaVersion: "1",
+		ConnectTimeoutMS: 30000,
+		ReadTimeoutMS:    60000,
+		WriteTimeoutMS:   60000,
+		BulkTimeoutMS:    360000,
+		MinPoolSize:      5,
+		MaxPoolSize:      10,
+		MaxIdleTimeMS:    360000,
+		Host:             "lenoobdev.l8ckp.mongodb.net",
+		Username:         "lenoob_dev",
+		Password:         "lenoob_dev",
+		Dbname:           "povi",
+		SchemaVersion:    "7",
 		Colnames: map[string]string{
 			"vanguard_fund_list":         "vanguard_fund_list",
 			"vanguard_fund_overview":     "vanguard_fund_overview",
 			"vanguard_fund_holding":      "vanguard_fund_holding",
 			"vanguard_fund_distribution": "vanguard_fund_distribution",
+			"vanguard_fund_performance":  "vanguard_fund_performance",
+			"vanguard_fund_change":       "vanguard_fund_change",
+			"vanguard_migration":         "vanguard_migration",
+			"vanguard_quarantine":        "vanguard_quarantine",
 		},
 	},
+	Validation: ValidationConfig{
+		OnError:            "QUARANTINE",
+		OnWarning:          "STORE",
+		WeightSumTolerance: 2,
+	},
+	Lookup: LookupConfig{
+		CountryAliasFile:      "data/country_aliases.json",
+		CountryMatchThreshold: 0.8,
+	},
 }
@@ -6,102 +6,118 @@ const (
 	VANGUARD_FUND_HOLDING_COLLECTION      = "vanguard_fund_holding"      // Should match with Colnames's key of AppConf
 	VANGUARD_FUND_OVERVIEW_COLLECTION     = "vanguard_fund_overview"     // Should match with Colnames's key of AppConf
 	VANGUARD_FUND_DISTRIBUTION_COLLECTION = "vanguard_fund_distribution" // Should match with Colnames's key of AppConf
+	VANGUARD_FUND_PERFORMANCE_COLLECTION  = "vanguard_fund_performance"  // Should match with Colnames's key of AppConf
+	VANGUARD_FUND_CHANGE_COLLECTION       = "vanguard_fund_change"       // Should match with Colnames's key of AppConf
+	VANGUARD_MIGRATION_COLLECTION         = "vanguard_migration"         // Should match with Colnames's key of AppConf
+	VANGUARD_QUARANTINE_COLLECTION        = "vanguard_quarantine"        // Should match with Colnames's key of AppConf
 )
 
+// WEIGHT_SHIFT_THRESHOLD is the smallest sector or country weight shift, in percentage points, reported as a change
+const WEIGHT_SHIFT_THRESHOLD = 1.0
+
+// FUND_DOMICILE is the ISO 3166 country code of the domicile of Vanguard Canada funds
+const FUND_DOMICILE = "CA"
+
+// Regions of a country
+const (
+	REGION_NORTH_AMERICA = "NORTH_AMERICA"
+	REGION_LATIN_AMERICA = "LATIN_AMERICA"
+	REGION_EUROPE        = "EUROPE"
+	REGION_MIDDLE_EAST   = "MIDDLE_EAST"
+	REGION_AFRICA        = "AFRICA"
+	REGION_ASIA_PACIFIC  = "ASIA_PACIFIC"
+	REGION_OTHER         = "OTHER"
+)
+
+// Market classifications of a country
+const (
+	MARKET_DEVELOPED    = "DEVELOPED"
+	MARKET_EMERGING     = "EMERGING"
+	MARKET_FRONTIER     = "FRONTIER"
+	MARKET_UNCLASSIFIED = "UNCLASSIFIED"
+)
+
+// NorthAmericaCountries are the alpha-3 codes of the countries of the Americas in North America, the others are in Latin America
+var NorthAmericaCountries = map[string]bool{
+	"USA": true, "CAN": true, "BMU": true, "GRL": true, "SPM": true,
+}
+
+// CountryRegions are the alpha-3 codes of the countries whose region is not the one of their latitude and longitude
+var CountryRegions = map[string]string{
+	"RUS": REGION_EUROPE,
+	"SYR": REGION_MIDDLE_EAST,
+	"ERI": REGION_AFRICA,
+	"ATA": REGION_OTHER,
+	"ATF": REGION_OTHER,
+	"BVT": REGION_OTHER,
+	"HMD": REGION_OTHER,
+	"SGS": REGION_OTHER,
+}
+
+// MarketClassifications maps alpha-3 codes to the MSCI market classification of 2021, other countries are unclassified
+var MarketClassifications = map[string]string{
+	"AUS": MARKET_DEVELOPED, "AUT": MARKET_DEVELOPED, "BEL": MARKET_DEVELOPED, "CAN": MARKET_DEVELOPED,
+	"CHE": MARKET_DEVELOPED, "DEU": MARKET_DEVELOPED, "DNK": MARKET_DEVELOPED, "ESP": MARKET_DEVELOPED,
+	"FIN": MARKET_DEVELOPED, "FRA": MARKET_DEVELOPED, "GBR": MARKET_DEVELOPED, "HKG": MARKET_DEVELOPED,
+	"IRL": MARKET_DEVELOPED, "ISR": MARKET_DEVELOPED, "ITA": MARKET_DEVELOPED, "JPN": MARKET_DEVELOPED,
+	"NLD": MARKET_DEVELOPED, "NOR": MARKET_DEVELOPED, "NZL": MARKET_DEVELOPED, "PRT": MARKET_DEVELOPED,
+	"SGP": MARKET_DEVELOPED, "SWE": MARKET_DEVELOPED, "USA": MARKET_DEVELOPED,
+
+	"ARE": MARKET_EMERGING, "BRA": MARKET_EMERGING, "CHL": MARKET_EMERGING, "CHN": MARKET_EMERGING,
+	"COL": MARKET_EMERGING, "CZE": MARKET_EMERGING, "EGY": MARKET_EMERGING, "GRC": MARKET_EMERGING,
+	"HUN": MARKET_EMERGING, "IDN": MARKET_EMERGING, "IND": MARKET_EMERGING, "KOR": MARKET_EMERGING,
+	"KWT": MARKET_EMERGING, "MEX": MARKET_EMERGING, "MYS": MARKET_EMERGING, "PER": MARKET_EMERGING,
+	"PHL": MARKET_EMERGING, "POL": MARKET_EMERGING, "QAT": MARKET_EMERGING, "RUS": MARKET_EMERGING,
+	"SAU": MARKET_EMERGING, "THA": MARKET_EMERGING, "TUR": MARKET_EMERGING, "TWN": MARKET_EMERGING,
+	"ZAF": MARKET_EMERGING,
+
+	"BEN": MARKET_FRONTIER, "BFA": MARKET_FRONTIER, "BGD": MARKET_FRONTIER, "BHR": MARKET_FRONTIER,
+	"CIV": MARKET_FRONTIER, "EST": MARKET_FRONTIER, "GNB": MARKET_FRONTIER, "HRV": MARKET_FRONTIER,
+	"ISL": MARKET_FRONTIER, "JOR": MARKET_FRONTIER, "KAZ": MARKET_FRONTIER, "KEN": MARKET_FRONTIER,
+	"LKA": MARKET_FRONTIER, "LTU": MARKET_FRONTIER, "MAR": MARKET_FRONTIER, "MLI": MARKET_FRONTIER,
+	"MUS": MARKET_FRONTIER, "NER": MARKET_FRONTIER, "NGA": MARKET_FRONTIER, "OMN": MARKET_FRONTIER,
+	"PAK": MARKET_FRONTIER, "ROU": MARKET_FRONTIER, "SEN": MARKET_FRONTIER, "SRB": MARKET_FRONTIER,
+	"SVN": MARKET_FRONTIER, "TGO": MARKET_FRONTIER, "TUN": MARKET_FRONTIER, "VNM": MARKET_FRONTIER,
+}
+
 const (
 	BOND     = "BOND"
 	EQUITY   = "EQUITY"
 	BALANCED = "BALANCED"
 )
 
-var Sectors = []struct {
-	Name string
-	Code string
-}{
-	{
-		Name: "Industrials",
-		Code: "IND",
-	},
-	{
-		Name: "Producer Durables",
-		Code: "IND",
-	},
-	{
-		Name: "Financials",
-		Code: "FIN",
-	},
-	{
-		Name: "Financial Services",
-		Code: "FIN",
-	},
-	{
-		Name: "Health Care",
-		Code: "HEC",
-	},
-	{
-		Name: "Consumer Discretionary",
-		Code: "CND",
-	},
-	{
-		Name: "Consumer Staples",
-		Code: "CNS",
-	},
-	{
-		Name: "Consumer Goods",
-		Code: "CNS",
-	},
-	{
-		Name: "Information Technology",
-		Code: "TEC",
-	},
-	{
-		Name: "Technology",
-		Code: "TEC",
-	},
-	{
-		Name: "Materials",
-		Code: "MTL",
-	},
-	{
-		Name: "Basic Materials",
-		Code: "MTL",
-	},
-	{
-		Name: "Materials & Processing",
-		Code: "MTL",
-	},
-	{
-		Name: "Energy",
-		Code: "ENG",
-	},
-	{
-		Name: "Oil & Gas",
-		Code: "ENG",
-	},
-	{
-		Name: "Utilities",
-		Code: "UTL",
-	},
-	{
-		Name: "Communication Services",
-		Code: "TEL",
-	},
-	{
-		Name: "Telecommunications",
-		Code: "TEL",
-	},
-	{
-		Name: "Real Estate",
-		Code: "RLE",
-	},
-	{
-		Name: "Other",
-		Code: "OTH",
-	},
-	{
-		Name: "Consumer Services",
-		Code: "OTH",
-	},
+// DividendSchedulePayments maps overview dividend schedule to the number of payments per year
+var DividendSchedulePayments = map[string]int{
+	"MONTHLY":       12,
+	"QUARTERLY":     4,
+	"SEMI-ANNUALLY": 2,
+	"SEMIANNUALLY":  2,
+	"ANNUALLY":      1,
+	"ANNUAL":        1,
+}
+
+// CurrencyCodes are the active ISO 4217 currency codes
+var CurrencyCodes = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true, "AUD": true,
+	"AWG": true, "AZN": true, "BAM": true, "BBD": true, "BDT": true, "BGN": true, "BHD": true, "BIF": true,
+	"BMD": true, "BND": true, "BOB": true, "BRL": true, "BSD": true, "BTN": true, "BWP": true, "BYN": true,
+	"BZD": true, "CAD": true, "CDF": true, "CHF": true, "CLP": true, "CNY": true, "COP": true, "CRC": true,
+	"CUP": true, "CVE": true, "CZK": true, "DJF": true, "DKK": true, "DOP": true, "DZD": true, "EGP": true,
+	"ERN": true, "ETB": true, "EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true,
+	"GIP": true, "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true, "HRK": true,
+	"HTG": true, "HUF": true, "IDR": true, "ILS": true, "INR": true, "IQD": true, "IRR": true, "ISK": true,
+	"JMD": true, "JOD": true, "JPY": true, "KES": true, "KGS": true, "KHR": true, "KMF": true, "KPW": true,
+	"KRW": true, "KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true, "LRD": true,
+	"LSL": true, "LYD": true, "MAD": true, "MDL": true, "MGA": true, "MKD": true, "MMK": true, "MNT": true,
+	"MOP": true, "MRU": true, "MUR": true, "MVR": true, "MWK": true, "MXN": true, "MYR": true, "MZN": true,
+	"NAD": true, "NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true, "OMR": true, "PAB": true,
+	"PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true, "RON": true,
+	"RSD": true, "RUB": true, "RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true,
+	"SGD": true, "SHP": true, "SLL": true, "SOS": true, "SRD": true, "SSP": true, "STN": true, "SVC": true,
+	"SYP": true, "SZL": true, "THB": true, "TJS": true, "TMT": true, "TND": true, "TOP": true, "TRY": true,
+	"TTD": true, "TWD": true, "TZS": true, "UAH": true, "UGX": true, "USD": true, "UYU": true, "UZS": true,
+	"VES": true, "VND": true, "VUV": true, "WST": true, "XAF": true, "XCD": true, "XOF": true, "XPF": true,
+	"YER": true, "ZAR": true, "ZMW": true, "ZWL": true,
 }
 
 var Countries = []struct {
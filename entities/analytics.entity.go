@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// DistributionAnalytics struct
+type DistributionAnalytics struct {
+	Ticker                  string              `json:"ticker,omitempty"`
+	Name                    string              `json:"name,omitempty"`
+	AsOf                    time.Time           `json:"asOf"`
+	Price                   decimal.Decimal     `json:"price"`
+	DividendSchedule        string              `json:"dividendSchedule,omitempty"`
+	ReportedYield12Month    decimal.Decimal     `json:"reportedYield12Month"`
+	ReportedDistYield       decimal.Decimal     `json:"reportedDistYield"`
+	TrailingAmount          decimal.Decimal     `json:"trailingAmount"`
+	TrailingYield           decimal.Decimal     `json:"trailingYield"`
+	ForwardYield            decimal.Decimal     `json:"forwardYield"`
+	GrowthRate              float64             `json:"growthRate"`
+	AnnualGrowthRate        float64             `json:"annualGrowthRate"`
+	ExpectedPaymentsPerYear int                 `json:"expectedPaymentsPerYear"`
+	PaymentConsistency      float64             `json:"paymentConsistency"`
+	RegularAmount           decimal.Decimal     `json:"regularAmount"`
+	SpecialAmount           decimal.Decimal     `json:"specialAmount"`
+	SpecialPercent          decimal.Decimal     `json:"specialPercent"`
+	Years                   []*YearDistribution `json:"years,omitempty"`
+}
+
+// YearDistribution struct
+type YearDistribution struct {
+	Year          int             `json:"year"`
+	Payments      int             `json:"payments"`
+	RegularAmount decimal.Decimal `json:"regularAmount"`
+	SpecialAmount decimal.Decimal `json:"specialAmount"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+
+// BondAnalytics struct is the bond holdings of a fund aggregated into distributions
+type BondAnalytics struct {
+	Ticker         string          `json:"ticker"`
+	AssetCode      string          `json:"assetCode"`
+	BondCount      int             `json:"bondCount"`
+	WeightedCoupon decimal.Decimal `json:"weightedCoupon"`
+	Coupons        []*BondBucket   `json:"coupons,omitempty"`
+	IssuerTypes    []*BondBucket   `json:"issuerTypes,omitempty"`
+}
+
+// BondBucket struct is the share of the bond holdings of a fund in a bucket, weights are percents of the bond holdings
+type BondBucket struct {
+	Bucket string          `json:"bucket"`
+	Count  int             `json:"count"`
+	Weight decimal.Decimal `json:"weight"`
+}
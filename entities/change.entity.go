@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// Change types detected between consecutive scrapes
+const (
+	CHANGE_TYPE_FUND_LISTED               = "FUND_LISTED"
+	CHANGE_TYPE_FUND_VANISHED             = "FUND_VANISHED"
+	CHANGE_TYPE_FUND_RELISTED             = "FUND_RELISTED"
+	CHANGE_TYPE_MER_FEE_CHANGED           = "MER_FEE_CHANGED"
+	CHANGE_TYPE_MANAGEMENT_FEE_CHANGED    = "MANAGEMENT_FEE_CHANGED"
+	CHANGE_TYPE_DIVIDEND_SCHEDULE_CHANGED = "DIVIDEND_SCHEDULE_CHANGED"
+	CHANGE_TYPE_NEW_DISTRIBUTION          = "NEW_DISTRIBUTION"
+	CHANGE_TYPE_SECTOR_WEIGHT_SHIFTED     = "SECTOR_WEIGHT_SHIFTED"
+	CHANGE_TYPE_COUNTRY_WEIGHT_SHIFTED    = "COUNTRY_WEIGHT_SHIFTED"
+)
+
+// FundChange struct
+type FundChange struct {
+	Ticker     string          `json:"ticker,omitempty"`
+	PortID     string          `json:"portId,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	Field      string          `json:"field,omitempty"`
+	OldValue   interface{}     `json:"oldValue,omitempty"`
+	NewValue   interface{}     `json:"newValue,omitempty"`
+	Delta      decimal.Decimal `json:"delta,omitempty"`
+	DetectedAt time.Time       `json:"detectedAt"`
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+
+// UnresolvedCountry struct is a scraped country name no country is resolved from, summed over the funds exposed to it
+type UnresolvedCountry struct {
+	CountryName string          `json:"countryName"`
+	Weight      decimal.Decimal `json:"weight"`  // sum of the fund market percents of all funds
+	Tickers     []string        `json:"tickers"` // exchange tickers of the funds
+}
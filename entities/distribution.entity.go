@@ -1,5 +1,11 @@
 package entities
 
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
 // FundDistribution struct
 type FundDistribution struct {
 	DistributionDetails struct {
@@ -11,11 +17,30 @@ type FundDistribution struct {
 
 // DistributionHistory struct
 type DistributionHistory struct {
-	Type               string  `json:"type,omitempty"`
-	DistributionAmount float64 `json:"distributionAmount,omitempty"`
-	ExDividendDate     string  `json:"exDividendDate,omitempty"`
-	RecordDate         string  `json:"recordDate,omitempty"`
-	PayableDate        string  `json:"payableDate,omitempty"`
-	DistDesc           string  `json:"distDesc,omitempty"`
-	DistCode           string  `json:"distCode,omitempty"`
+	Type               string          `json:"type,omitempty"`
+	DistributionAmount decimal.Decimal `json:"distributionAmount,omitempty"`
+	ExDividendDate     string          `json:"exDividendDate,omitempty"`
+	RecordDate         string          `json:"recordDate,omitempty"`
+	PayableDate        string          `json:"payableDate,omitempty"`
+	DistDesc           string          `json:"distDesc,omitempty"`
+	DistCode           string          `json:"distCode,omitempty"`
+}
+
+// FundDistributionRecord struct is a stored fund distribution
+type FundDistributionRecord struct {
+	ModifiedAt            int64                        `json:"modifiedAt,omitempty"` // unix seconds
+	PortID                string                       `json:"portId,omitempty"`
+	Ticker                string                       `json:"ticker,omitempty"`
+	DistributionHistories []*DistributionHistoryRecord `json:"distributionHistories,omitempty"`
+}
+
+// DistributionHistoryRecord struct
+type DistributionHistoryRecord struct {
+	Type               string          `json:"type,omitempty"`
+	DistributionAmount decimal.Decimal `json:"distributionAmount,omitempty"`
+	ExDividendDate     *time.Time      `json:"exDividendDate,omitempty"`
+	RecordDate         *time.Time      `json:"recordDate,omitempty"`
+	PayableDate        *time.Time      `json:"payableDate,omitempty"`
+	DistDesc           string          `json:"distDesc,omitempty"`
+	DistCode           string          `json:"distCode,omitempty"`
 }
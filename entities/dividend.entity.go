@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// DividendCalendar struct
+type DividendCalendar struct {
+	AsOf     time.Time        `json:"asOf"`
+	Upcoming []*DividendEvent `json:"upcoming,omitempty"`
+	Past     []*DividendEvent `json:"past,omitempty"`
+}
+
+// DividendEvent struct
+type DividendEvent struct {
+	Ticker           string          `json:"ticker,omitempty"`
+	Name             string          `json:"name,omitempty"`
+	DividendSchedule string          `json:"dividendSchedule,omitempty"`
+	Type             string          `json:"type,omitempty"`
+	Amount           decimal.Decimal `json:"amount,omitempty"`
+	ExDividendDate   *time.Time      `json:"exDividendDate,omitempty"`
+	RecordDate       *time.Time      `json:"recordDate,omitempty"`
+	PayableDate      *time.Time      `json:"payableDate,omitempty"`
+	DistDesc         string          `json:"distDesc,omitempty"`
+	DistCode         string          `json:"distCode,omitempty"`
+	Projected        bool            `json:"projected"`
+}
@@ -1,5 +1,7 @@
 package entities
 
+import "github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+
 // Fund struct
 type Fund struct {
 	Ticker        string `json:"TICKER,omitempty"`
@@ -12,3 +14,18 @@ type Fund struct {
 	ManagementFee string `json:"managementFee,omitempty"`
 	MerFee        string `json:"merValue,omitempty"`
 }
+
+// FundRecord struct is a stored fund
+type FundRecord struct {
+	ModifiedAt    int64            `json:"modifiedAt,omitempty"` // unix seconds
+	Ticker        string           `json:"ticker,omitempty"`
+	Symbology     *Symbology       `json:"symbology,omitempty"`
+	AssetCode     string           `json:"assetCode,omitempty"`
+	Name          string           `json:"name,omitempty"`
+	Currency      string           `json:"currency,omitempty"`
+	IssueType     string           `json:"issueType,omitempty"`
+	PortID        string           `json:"portId,omitempty"`
+	ProductType   string           `json:"productType,omitempty"`
+	ManagementFee *decimal.Decimal `json:"managementFee,omitempty"` // nil when the fund list has no fee
+	MerFee        *decimal.Decimal `json:"merFee,omitempty"`
+}
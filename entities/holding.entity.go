@@ -1,6 +1,6 @@
 package entities
 
-import "encoding/json"
+import "github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
 
 // FundHolding struct
 type FundHolding struct {
@@ -34,18 +34,64 @@ type BalancedHolding struct {
 
 // SectorWeightBond struct
 type SectorWeightBond struct {
-	MarketValPercent json.Number `json:"marketValPercent,omitempty"`
-	MarketValue      json.Number `json:"marketValue,omitempty"`
-	FaceAmount       float64     `json:"faceAmount,omitempty"`
-	Rate             float64     `json:"rate,omitempty"`
-	Type             string      `json:"type,omitempty"`
+	MarketValPercent decimal.Decimal `json:"marketValPercent,omitempty"`
+	MarketValue      decimal.Decimal `json:"marketValue,omitempty"`
+	FaceAmount       decimal.Decimal `json:"faceAmount,omitempty"`
+	Rate             decimal.Decimal `json:"rate,omitempty"`
+	Type             string          `json:"type,omitempty"`
 }
 
 // SectorWeightStock struct
 type SectorWeightStock struct {
-	MarketValPercent json.Number `json:"marketValPercent,omitempty"`
-	MarketValue      json.Number `json:"marketValue,omitempty"`
-	Shares           float64     `json:"shares,omitempty"`
-	Symbol           string      `json:"symbol,omitempty"`
-	Type             string      `json:"type,omitempty"`
+	MarketValPercent decimal.Decimal `json:"marketValPercent,omitempty"`
+	MarketValue      decimal.Decimal `json:"marketValue,omitempty"`
+	Shares           decimal.Decimal `json:"shares,omitempty"`
+	Symbol           string          `json:"symbol,omitempty"`
+	Type             string          `json:"type,omitempty"`
+}
+
+// TopHoldings struct
+type TopHoldings struct {
+	Ticker    string        `json:"ticker,omitempty"`
+	AssetCode string        `json:"assetCode,omitempty"`
+	Holdings  []*TopHolding `json:"holdings,omitempty"`
+}
+
+// TopHolding struct
+type TopHolding struct {
+	Rank             int             `json:"rank"`
+	Symbol           string          `json:"symbol,omitempty"`
+	Type             string          `json:"type,omitempty"`
+	MarketValPercent decimal.Decimal `json:"marketValPercent"`
+	MarketValue      decimal.Decimal `json:"marketValue"`
+	Shares           decimal.Decimal `json:"shares"`
+}
+
+// FundHoldingRecord struct is a stored fund holding
+type FundHoldingRecord struct {
+	ModifiedAt int64                      `json:"modifiedAt,omitempty"` // unix seconds
+	PortID     string                     `json:"portId,omitempty"`
+	Ticker     string                     `json:"ticker,omitempty"`
+	AssetCode  string                     `json:"assetCode,omitempty"`
+	Bonds      []*SectorWeightBondRecord  `json:"bondHolding,omitempty"`
+	Stocks     []*SectorWeightStockRecord `json:"stockHolding,omitempty"`
+}
+
+// SectorWeightBondRecord struct
+type SectorWeightBondRecord struct {
+	FaceAmount       decimal.Decimal `json:"faceAmount,omitempty"`
+	MarketValPercent decimal.Decimal `json:"marketValPercent,omitempty"`
+	MarketValue      decimal.Decimal `json:"marketValue,omitempty"`
+	Rate             decimal.Decimal `json:"rate,omitempty"`
+	Type             string          `json:"type,omitempty"`
+}
+
+// SectorWeightStockRecord struct
+type SectorWeightStockRecord struct {
+	MarketValPercent decimal.Decimal `json:"marketValPercent,omitempty"`
+	MarketValue      decimal.Decimal `json:"marketValue,omitempty"`
+	Shares           decimal.Decimal `json:"shares,omitempty"`
+	Symbol           string          `json:"symbol,omitempty"`
+	Type             string          `json:"type,omitempty"`
+	Rank             int             `json:"rank,omitempty"` // position by weight in the fund, the largest is 1
 }
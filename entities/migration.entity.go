@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// Migration directions
+const (
+	MIGRATION_DIRECTION_UP   = "UP"
+	MIGRATION_DIRECTION_DOWN = "DOWN"
+)
+
+// MigrationResult struct is the outcome of moving the documents of a collection from one schema version to the next
+type MigrationResult struct {
+	Collection  string     `json:"collection,omitempty"`
+	Direction   string     `json:"direction,omitempty"`
+	FromVersion string     `json:"fromVersion,omitempty"`
+	ToVersion   string     `json:"toVersion,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Documents   int        `json:"documents"`
+	DryRun      bool       `json:"dryRun"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+
+// Overlap levels
+const (
+	OVERLAP_LEVEL_SECURITY = "SECURITY"
+	OVERLAP_LEVEL_SECTOR   = "SECTOR"
+	OVERLAP_LEVEL_COUNTRY  = "COUNTRY"
+)
+
+// FundOverlap struct
+type FundOverlap struct {
+	Tickers        []string         `json:"tickers,omitempty"`
+	Level          string           `json:"level,omitempty"`
+	OverlapPercent decimal.Decimal  `json:"overlapPercent"`
+	SharedCount    int              `json:"sharedCount"`
+	SectorOverlap  decimal.Decimal  `json:"sectorOverlap"`
+	CountryOverlap decimal.Decimal  `json:"countryOverlap"`
+	TopOverlaps    []*SharedHolding `json:"topOverlaps,omitempty"`
+	Pairs          []*FundOverlap   `json:"pairs,omitempty"`
+	MissingTickers []string         `json:"missingTickers,omitempty"`
+}
+
+// SharedHolding struct
+type SharedHolding struct {
+	Symbol        string                     `json:"symbol,omitempty"`
+	OverlapWeight decimal.Decimal            `json:"overlapWeight"`
+	Weights       map[string]decimal.Decimal `json:"weights,omitempty"`
+}
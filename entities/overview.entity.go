@@ -1,5 +1,11 @@
 package entities
 
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
 // FundOverview struct
 type FundOverview struct {
 	PortID           string              `json:"portId,omitempty"`
@@ -10,15 +16,15 @@ type FundOverview struct {
 	Name             string              `json:"name,omitempty"`
 	ShortName        string              `json:"shortName,omitempty"`
 	Yield12Month     string              `json:"yield12Month,omitempty"`
-	Price            float64             `json:"price,omitempty"`
+	Price            decimal.NullDecimal `json:"price,omitempty"`
 	BaseCurrency     string              `json:"baseCurrency,omitempty"`
 	ManagementFee    string              `json:"managementFee,omitempty"`
 	MerFee           string              `json:"merValue,omitempty"`
 	DistYield        string              `json:"distYield,omitempty"`
 	DistAmount       string              `json:"incomeDistributionAmount,omitempty"`
-	AllocationStock  float64             `json:"allocationStock,omitempty"`
-	AllocationBond   float64             `json:"allocationBond,omitempty"`
-	AllocationCash   float64             `json:"allocationCash,omitempty"`
+	AllocationStock  decimal.Decimal     `json:"allocationStock,omitempty"`
+	AllocationBond   decimal.Decimal     `json:"allocationBond,omitempty"`
+	AllocationCash   decimal.Decimal     `json:"allocationCash,omitempty"`
 	FundCode         *FundCode           `json:"fundCodesData,omitempty"`
 	Sectors          []*SectorBreakdown  `json:"sectorWeighting,omitempty"`
 	Countries        []*CountryBreakdown `json:"countryExposure,omitempty"`
@@ -53,3 +59,67 @@ type DividendHistory struct {
 	Amount       string `json:"amount,omitempty"`
 	CurrencyCode string `json:"currencyCode,omitempty"`
 }
+
+// FundOverviewRecord struct is a stored fund overview, sectors are classified under the SectorTaxonomy version
+type FundOverviewRecord struct {
+	ModifiedAt       int64                     `json:"modifiedAt,omitempty"` // unix seconds
+	PortID           string                    `json:"portId,omitempty"`
+	AssetClass       string                    `json:"assetClass,omitempty"`
+	Strategy         string                    `json:"strategy,omitempty"`
+	DividendSchedule string                    `json:"dividendSchedule,omitempty"`
+	Name             string                    `json:"name,omitempty"`
+	ShortName        string                    `json:"shortName,omitempty"`
+	Currency         string                    `json:"currency,omitempty"`
+	Isin             string                    `json:"isin,omitempty"`
+	Sedol            string                    `json:"sedol,omitempty"`
+	Cusip            string                    `json:"cusip,omitempty"`
+	Ticker           string                    `json:"ticker,omitempty"`
+	Symbology        *Symbology                `json:"symbology,omitempty"`
+	TotalAssets      decimal.Decimal           `json:"totalAssets,omitempty"`
+	Yield12Month     decimal.Decimal           `json:"yield12Month,omitempty"`
+	Price            decimal.Decimal           `json:"price,omitempty"`
+	ManagementFee    decimal.Decimal           `json:"managementFee,omitempty"`
+	MerFee           decimal.Decimal           `json:"merFee,omitempty"`
+	DistYield        decimal.Decimal           `json:"distYield,omitempty"`
+	DistAmount       decimal.Decimal           `json:"distAmount,omitempty"`
+	AllocationStock  decimal.Decimal           `json:"allocationStock,omitempty"`
+	AllocationBond   decimal.Decimal           `json:"allocationBond,omitempty"`
+	AllocationCash   decimal.Decimal           `json:"allocationCash,omitempty"`
+	SectorTaxonomy   string                    `json:"sectorTaxonomy,omitempty"`
+	Sectors          []*SectorBreakdownRecord  `json:"sectors,omitempty"`
+	Countries        []*CountryBreakdownRecord `json:"countries,omitempty"`
+	Regions          []*CountryGroupRecord     `json:"regions,omitempty"`
+	Markets          []*CountryGroupRecord     `json:"markets,omitempty"`
+	Dividends        []*DividendHistoryRecord  `json:"dividends,omitempty"`
+}
+
+// SectorBreakdownRecord struct
+type SectorBreakdownRecord struct {
+	SectorCode        string          `json:"sectorCode,omitempty"`
+	IndustryGroupCode string          `json:"industryGroupCode,omitempty"`
+	SectorName        string          `json:"sectorName,omitempty"`
+	FundPercent       decimal.Decimal `json:"fundPercent,omitempty"`
+}
+
+// CountryBreakdownRecord struct
+type CountryBreakdownRecord struct {
+	CountryCode     string          `json:"countryCode,omitempty"`
+	CountryName     string          `json:"countryName,omitempty"`
+	FundMktPercent  decimal.Decimal `json:"fundMktPercent,omitempty"`
+	FundTnaPercent  decimal.Decimal `json:"fundTnaPercent,omitempty"`
+	HoldingStatCode string          `json:"holdingStatCode,omitempty"`
+}
+
+// CountryGroupRecord struct is the exposure to a region or a market classification
+type CountryGroupRecord struct {
+	GroupCode      string          `json:"groupCode,omitempty"`
+	FundMktPercent decimal.Decimal `json:"fundMktPercent,omitempty"`
+	FundTnaPercent decimal.Decimal `json:"fundTnaPercent,omitempty"`
+}
+
+// DividendHistoryRecord struct
+type DividendHistoryRecord struct {
+	Amount       decimal.Decimal `json:"amount,omitempty"`
+	CurrencyCode string          `json:"currencyCode,omitempty"`
+	AsOfDate     *time.Time      `json:"asOfDate,omitempty"`
+}
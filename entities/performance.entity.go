@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// FundPerformance struct
+type FundPerformance struct {
+	PortID             string           `json:"portId,omitempty"`
+	Ticker             string           `json:"ticker,omitempty"`
+	AsOfDate           string           `json:"asOfDate,omitempty"`
+	InceptionDate      string           `json:"inceptionDate,omitempty"`
+	BenchmarkName      string           `json:"benchmarkName,omitempty"`
+	NavReturns         *TrailingReturns `json:"navReturns,omitempty"`
+	MarketPriceReturns *TrailingReturns `json:"marketPriceReturns,omitempty"`
+	BenchmarkReturns   *TrailingReturns `json:"benchmarkReturns,omitempty"`
+}
+
+// TrailingReturns struct are percent returns, annualized over periods longer than a year
+type TrailingReturns struct {
+	OneMonth       string `json:"oneMonth,omitempty"`
+	ThreeMonth     string `json:"threeMonth,omitempty"`
+	YearToDate     string `json:"yearToDate,omitempty"`
+	OneYear        string `json:"oneYear,omitempty"`
+	ThreeYear      string `json:"threeYear,omitempty"`
+	FiveYear       string `json:"fiveYear,omitempty"`
+	TenYear        string `json:"tenYear,omitempty"`
+	SinceInception string `json:"sinceInception,omitempty"`
+}
+
+// IsUnpublishedReturn checks if a scraped return is missing, vanguard publishes a dash for a period older than the fund
+func IsUnpublishedReturn(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "" || value == "-" || value == "—"
+}
+
+// FundReturns struct
+type FundReturns struct {
+	Ticker        string        `json:"ticker,omitempty"`
+	AsOfDate      *time.Time    `json:"asOfDate,omitempty"`
+	InceptionDate *time.Time    `json:"inceptionDate,omitempty"`
+	BenchmarkName string        `json:"benchmarkName,omitempty"`
+	Nav           *ReturnSeries `json:"nav,omitempty"`
+	MarketPrice   *ReturnSeries `json:"marketPrice,omitempty"`
+	Benchmark     *ReturnSeries `json:"benchmark,omitempty"`
+}
+
+// ReturnSeries struct, a return is nil if it is not published
+type ReturnSeries struct {
+	OneMonth       *decimal.Decimal `json:"oneMonth,omitempty"`
+	ThreeMonth     *decimal.Decimal `json:"threeMonth,omitempty"`
+	YearToDate     *decimal.Decimal `json:"yearToDate,omitempty"`
+	OneYear        *decimal.Decimal `json:"oneYear,omitempty"`
+	ThreeYear      *decimal.Decimal `json:"threeYear,omitempty"`
+	FiveYear       *decimal.Decimal `json:"fiveYear,omitempty"`
+	TenYear        *decimal.Decimal `json:"tenYear,omitempty"`
+	SinceInception *decimal.Decimal `json:"sinceInception,omitempty"`
+}
+
+// FundPerformanceRecord struct is a stored fund performance, a return is nil if it is not published
+type FundPerformanceRecord struct {
+	ModifiedAt         int64         `json:"modifiedAt,omitempty"` // unix seconds
+	PortID             string        `json:"portId,omitempty"`
+	Ticker             string        `json:"ticker,omitempty"`
+	AsOfDate           *time.Time    `json:"asOfDate,omitempty"`
+	InceptionDate      *time.Time    `json:"inceptionDate,omitempty"`
+	BenchmarkName      string        `json:"benchmarkName,omitempty"`
+	NavReturns         *ReturnSeries `json:"navReturns,omitempty"`
+	MarketPriceReturns *ReturnSeries `json:"marketPriceReturns,omitempty"`
+	BenchmarkReturns   *ReturnSeries `json:"benchmarkReturns,omitempty"`
+}
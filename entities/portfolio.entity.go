@@ -0,0 +1,29 @@
+package entities
+
+import "github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+
+// PortfolioPosition struct
+type PortfolioPosition struct {
+	Ticker string          `json:"ticker,omitempty"`
+	Weight decimal.Decimal `json:"weight,omitempty"`
+}
+
+// PortfolioExposure struct
+type PortfolioExposure struct {
+	Positions       []*PortfolioPosition `json:"positions,omitempty"`
+	AllocationStock decimal.Decimal      `json:"allocationStock"`
+	AllocationBond  decimal.Decimal      `json:"allocationBond"`
+	AllocationCash  decimal.Decimal      `json:"allocationCash"`
+	WeightedMer     decimal.Decimal      `json:"weightedMer"`
+	Sectors         []*ExposureWeight    `json:"sectors,omitempty"`
+	Countries       []*ExposureWeight    `json:"countries,omitempty"`
+	Holdings        []*ExposureWeight    `json:"holdings,omitempty"`
+	MissingTickers  []string             `json:"missingTickers,omitempty"`
+}
+
+// ExposureWeight struct
+type ExposureWeight struct {
+	Code   string          `json:"code,omitempty"`
+	Name   string          `json:"name,omitempty"`
+	Weight decimal.Decimal `json:"weight"`
+}
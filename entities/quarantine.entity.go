@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// Quarantine statuses
+const (
+	QUARANTINE_STATUS_PENDING   = "PENDING"
+	QUARANTINE_STATUS_APPROVED  = "APPROVED"
+	QUARANTINE_STATUS_DISCARDED = "DISCARDED"
+)
+
+// QuarantinedItem struct is a scraped document held back by validation, only the raw entity matching its kind is set
+type QuarantinedItem struct {
+	ID            string             `json:"id,omitempty"`
+	RunID         string             `json:"runId,omitempty"`
+	Kind          string             `json:"kind,omitempty"`
+	Ticker        string             `json:"ticker,omitempty"`
+	PortID        string             `json:"portId,omitempty"`
+	Status        string             `json:"status,omitempty"`
+	Issues        []*ValidationIssue `json:"issues,omitempty"`
+	QuarantinedAt *time.Time         `json:"quarantinedAt,omitempty"`
+	ResolvedAt    *time.Time         `json:"resolvedAt,omitempty"`
+	Fund          *Fund              `json:"fund,omitempty"`
+	Overview      *FundOverview      `json:"overview,omitempty"`
+	Holding       *FundHolding       `json:"holding,omitempty"`
+	Distribution  *FundDistribution  `json:"distribution,omitempty"`
+	Performance   *FundPerformance   `json:"performance,omitempty"`
+}
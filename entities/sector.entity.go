@@ -0,0 +1,19 @@
+package entities
+
+import "github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+
+// FundSectors struct is the sector breakdown of a fund under a sector taxonomy version
+type FundSectors struct {
+	Ticker   string          `json:"ticker"`
+	Taxonomy string          `json:"taxonomy"`
+	Sectors  []*SectorWeight `json:"sectors,omitempty"`
+}
+
+// SectorWeight struct is the weight of a sector, or of one of its industry groups if known
+type SectorWeight struct {
+	SectorCode        string          `json:"sectorCode"`
+	SectorName        string          `json:"sectorName"`
+	IndustryGroupCode string          `json:"industryGroupCode,omitempty"`
+	IndustryGroupName string          `json:"industryGroupName,omitempty"`
+	Weight            decimal.Decimal `json:"weight"`
+}
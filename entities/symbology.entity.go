@@ -0,0 +1,13 @@
+package entities
+
+// Symbology struct holds the identifiers of a fund in every vendor format
+type Symbology struct {
+	Exchange  string `json:"exchange,omitempty"`
+	Yahoo     string `json:"yahoo,omitempty"`
+	Google    string `json:"google,omitempty"`
+	Bloomberg string `json:"bloomberg,omitempty"`
+	Refinitiv string `json:"refinitiv,omitempty"`
+	Isin      string `json:"isin,omitempty"`
+	Sedol     string `json:"sedol,omitempty"`
+	Cusip     string `json:"cusip,omitempty"`
+}
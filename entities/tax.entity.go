@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// Tax categories of a distribution
+const (
+	TAX_CATEGORY_INCOME                  = "INCOME"
+	TAX_CATEGORY_ELIGIBLE_DIVIDEND       = "ELIGIBLE_DIVIDEND"
+	TAX_CATEGORY_FOREIGN_INCOME          = "FOREIGN_INCOME"
+	TAX_CATEGORY_INTEREST                = "INTEREST"
+	TAX_CATEGORY_CAPITAL_GAIN            = "CAPITAL_GAIN"
+	TAX_CATEGORY_RETURN_OF_CAPITAL       = "RETURN_OF_CAPITAL"
+	TAX_CATEGORY_REINVESTED_CAPITAL_GAIN = "REINVESTED_CAPITAL_GAIN"
+	TAX_CATEGORY_UNKNOWN                 = "UNKNOWN"
+)
+
+// TaxSummary struct approximates the T3 boxes of a fund for a tax year, amounts are per unit
+type TaxSummary struct {
+	Ticker                   string                    `json:"ticker,omitempty"`
+	Name                     string                    `json:"name,omitempty"`
+	Year                     int                       `json:"year"`
+	CapitalGains             decimal.Decimal           `json:"capitalGains"`             // box 21
+	ForeignIncome            decimal.Decimal           `json:"foreignIncome"`            // box 25
+	OtherIncome              decimal.Decimal           `json:"otherIncome"`              // box 26
+	ReturnOfCapital          decimal.Decimal           `json:"returnOfCapital"`          // box 42
+	EligibleDividends        decimal.Decimal           `json:"eligibleDividends"`        // box 49
+	TaxableEligibleDividends decimal.Decimal           `json:"taxableEligibleDividends"` // box 50
+	DividendTaxCredit        decimal.Decimal           `json:"dividendTaxCredit"`        // box 51
+	ReinvestedCapitalGains   decimal.Decimal           `json:"reinvestedCapitalGains"`
+	AcbAdjustment            decimal.Decimal           `json:"acbAdjustment"`
+	TotalCash                decimal.Decimal           `json:"totalCash"`
+	Distributions            []*ClassifiedDistribution `json:"distributions,omitempty"`
+}
+
+// ClassifiedDistribution struct
+type ClassifiedDistribution struct {
+	Type        string          `json:"type,omitempty"`
+	DistCode    string          `json:"distCode,omitempty"`
+	DistDesc    string          `json:"distDesc,omitempty"`
+	Amount      decimal.Decimal `json:"amount"`
+	RecordDate  *time.Time      `json:"recordDate,omitempty"`
+	TaxCategory string          `json:"taxCategory,omitempty"`
+}
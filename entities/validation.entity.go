@@ -0,0 +1,79 @@
+package entities
+
+// Validation actions decided by the validation policy
+const (
+	VALIDATION_ACTION_STORE      = "STORE"
+	VALIDATION_ACTION_QUARANTINE = "QUARANTINE"
+	VALIDATION_ACTION_REJECT     = "REJECT"
+)
+
+// Validation severities, an absent value is reported without deciding the action
+const (
+	VALIDATION_SEVERITY_ABSENT  = "ABSENT"
+	VALIDATION_SEVERITY_WARNING = "WARNING"
+	VALIDATION_SEVERITY_ERROR   = "ERROR"
+)
+
+// Validation rules
+const (
+	VALIDATION_RULE_NUMBER         = "NUMBER"
+	VALIDATION_RULE_PERCENT_RANGE  = "PERCENT_RANGE"
+	VALIDATION_RULE_WEIGHT_SUM     = "WEIGHT_SUM"
+	VALIDATION_RULE_NON_NEGATIVE   = "NON_NEGATIVE"
+	VALIDATION_RULE_POSITIVE       = "POSITIVE"
+	VALIDATION_RULE_PRESENT        = "PRESENT"
+	VALIDATION_RULE_NOT_EMPTY      = "NOT_EMPTY"
+	VALIDATION_RULE_ISIN_CHECKSUM  = "ISIN_CHECKSUM"
+	VALIDATION_RULE_ISIN_COUNTRY   = "ISIN_COUNTRY"
+	VALIDATION_RULE_SEDOL_CHECKSUM = "SEDOL_CHECKSUM"
+	VALIDATION_RULE_CURRENCY_CODE  = "CURRENCY_CODE"
+	VALIDATION_RULE_DATE           = "DATE"
+)
+
+// Validated documents
+const (
+	VALIDATION_KIND_FUND         = "FUND"
+	VALIDATION_KIND_OVERVIEW     = "OVERVIEW"
+	VALIDATION_KIND_HOLDING      = "HOLDING"
+	VALIDATION_KIND_DISTRIBUTION = "DISTRIBUTION"
+	VALIDATION_KIND_PERFORMANCE  = "PERFORMANCE"
+)
+
+// ValidationResult struct is the outcome of validating a scraped document of a fund
+type ValidationResult struct {
+	Ticker string             `json:"ticker,omitempty"`
+	PortID string             `json:"portId,omitempty"`
+	Kind   string             `json:"kind,omitempty"`
+	Action string             `json:"action,omitempty"`
+	Issues []*ValidationIssue `json:"issues,omitempty"`
+}
+
+// ValidationIssue struct is a failed validation rule
+type ValidationIssue struct {
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// HasErrors checks if any issue of the result is an error
+func (r *ValidationResult) HasErrors() bool {
+	return r.hasSeverity(VALIDATION_SEVERITY_ERROR)
+}
+
+// HasWarnings checks if any issue of the result is a warning
+func (r *ValidationResult) HasWarnings() bool {
+	return r.hasSeverity(VALIDATION_SEVERITY_WARNING)
+}
+
+// hasSeverity checks if any issue of the result has the severity
+func (r *ValidationResult) hasSeverity(severity string) bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,6 @@
 module github.com/lenoobz/aws-vanguard-ca-etf-scraper
 
-go 1.14
+go 1.15
 
 require (
 	github.com/PuerkitoBio/goquery v1.6.0 // indirect
@@ -17,13 +17,16 @@ require (
 	github.com/lenoobz/aws-lambda-corid v0.0.0-20210726202238-53751e0ade36
 	github.com/lenoobz/aws-lambda-logger v0.0.0-20210726205244-4eae893f1aa9
 	github.com/saintfish/chardet v0.0.0-20120816061221-3af4cd4741ca // indirect
+	github.com/shopspring/decimal v1.2.0
 	github.com/temoto/robotstxt v1.1.1 // indirect
+	github.com/xitongsys/parquet-go v1.5.4
+	github.com/xitongsys/parquet-go-source v0.0.0-20200817004010-026bad9b25d0
+	github.com/xuri/excelize/v2 v2.4.1
 	go.mongodb.org/mongo-driver v1.4.4
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.7.0 // indirect
 	go.uber.org/zap v1.18.1 // indirect
-	golang.org/x/net v0.0.0-20201209123823-ac852fbbde11 // indirect
-	golang.org/x/text v0.3.4 // indirect
+	golang.org/x/text v0.3.6
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/protobuf v1.25.0 // indirect
 )
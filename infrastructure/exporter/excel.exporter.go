@@ -0,0 +1,384 @@
+package exporter
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/export"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+	"github.com/xuri/excelize/v2"
+)
+
+// summarySheet is the name of the first sheet of the workbook
+const summarySheet = "Summary"
+
+// maxSheetNameLength is the longest sheet name excel accepts
+const maxSheetNameLength = 31
+
+// Number formats used by the workbook
+const (
+	excelTextFormat    = "@"
+	excelPriceFormat   = "$#,##0.00"
+	excelAmountFormat  = "0.0000##"
+	excelAssetsFormat  = "$#,##0"
+	excelNumberFormat  = "#,##0.##"
+	excelPercentFormat = `0.00"%"` // percentages are stored as 0-100 values
+	excelDateFormat    = "yyyy-mm-dd"
+)
+
+// ExcelExporter struct
+type ExcelExporter struct {
+	exportService *export.Service
+	outFile       string
+	log           logger.ContextLog
+}
+
+// NewExcelExporter create new excel exporter
+func NewExcelExporter(exportService *export.Service, outFile string, log logger.ContextLog) *ExcelExporter {
+	return &ExcelExporter{
+		exportService: exportService,
+		outFile:       outFile,
+		log:           log,
+	}
+}
+
+// Export writes a workbook with a summary sheet and one sheet per fund
+func (e *ExcelExporter) Export(ctx context.Context) error {
+	dataset, err := e.exportService.GetDataset(ctx)
+	if err != nil {
+		e.log.Error(ctx, "get export dataset failed", "error", err)
+		return err
+	}
+
+	f := excelize.NewFile()
+
+	styles, err := newExcelStyles(f)
+	if err != nil {
+		e.log.Error(ctx, "create excel styles failed", "error", err)
+		return err
+	}
+
+	// sort overviews by ticker so sheets are in a stable order
+	overviews := append([]*entities.FundOverviewRecord{}, dataset.Overviews...)
+	sort.Slice(overviews, func(i, j int) bool {
+		return overviews[i].Ticker < overviews[j].Ticker
+	})
+
+	f.SetSheetName(f.GetSheetName(0), summarySheet)
+	if err := writeSummarySheet(f, styles, overviews); err != nil {
+		e.log.Error(ctx, "write summary sheet failed", "error", err)
+		return err
+	}
+
+	holdings := make(map[string]*entities.FundHoldingRecord)
+	for _, holding := range dataset.Holdings {
+		holdings[holding.Ticker] = holding
+	}
+
+	distributions := make(map[string]*entities.FundDistributionRecord)
+	for _, distribution := range dataset.Distributions {
+		distributions[distribution.Ticker] = distribution
+	}
+
+	for _, overview := range overviews {
+		if err := writeFundSheet(f, styles, overview, holdings[overview.Ticker], distributions[overview.Ticker]); err != nil {
+			e.log.Error(ctx, "write fund sheet failed", "ticker", overview.Ticker, "error", err)
+			return err
+		}
+	}
+
+	f.SetActiveSheet(0)
+	if err := f.SaveAs(e.outFile); err != nil {
+		e.log.Error(ctx, "save workbook failed", "file", e.outFile, "error", err)
+		return err
+	}
+
+	e.log.Info(ctx, "write excel workbook", "file", e.outFile, "funds", len(overviews))
+	return nil
+}
+
+///////////////////////////////////////////////////////////
+// Sheets
+///////////////////////////////////////////////////////////
+
+// writeSummarySheet lists price, fees, yield, total assets and allocation of every fund
+func writeSummarySheet(f *excelize.File, styles *excelStyles, overviews []*entities.FundOverviewRecord) error {
+	w := newExcelSheetWriter(f, styles, summarySheet)
+
+	w.header("Ticker", "Name", "Asset Class", "Currency", "Price", "MER", "Management Fee", "12M Yield", "Dist Yield", "Total Assets", "Stock", "Bond", "Cash")
+	for _, overview := range overviews {
+		w.row(
+			excelCell{overview.Ticker, styles.text},
+			excelCell{overview.Name, styles.text},
+			excelCell{overview.AssetClass, styles.text},
+			excelCell{overview.Currency, styles.text},
+			excelCell{overview.Price, styles.price},
+			excelCell{overview.MerFee, styles.percent},
+			excelCell{overview.ManagementFee, styles.percent},
+			excelCell{overview.Yield12Month, styles.percent},
+			excelCell{overview.DistYield, styles.percent},
+			excelCell{overview.TotalAssets, styles.assets},
+			excelCell{overview.AllocationStock, styles.percent},
+			excelCell{overview.AllocationBond, styles.percent},
+			excelCell{overview.AllocationCash, styles.percent},
+		)
+	}
+
+	if err := f.SetColWidth(summarySheet, "B", "B", 60); err != nil {
+		return err
+	}
+
+	if err := f.SetPanes(summarySheet, `{"freeze":true,"x_split":1,"y_split":1,"top_left_cell":"B2","active_pane":"bottomRight"}`); err != nil {
+		return err
+	}
+
+	return w.err
+}
+
+// writeFundSheet writes sector breakdown, country exposure, holdings and distribution history of a fund
+func writeFundSheet(f *excelize.File, styles *excelStyles, overview *entities.FundOverviewRecord, holding *entities.FundHoldingRecord, distribution *entities.FundDistributionRecord) error {
+	sheet := newSheetName(overview.Ticker)
+	f.NewSheet(sheet)
+
+	w := newExcelSheetWriter(f, styles, sheet)
+
+	w.title(overview.Name)
+	w.blank()
+
+	w.title("Sector Breakdown")
+	w.header("Code", "Sector", "Weight")
+	for _, sector := range overview.Sectors {
+		w.row(
+			excelCell{sector.SectorCode, styles.text},
+			excelCell{sector.SectorName, styles.text},
+			excelCell{sector.FundPercent, styles.percent},
+		)
+	}
+	w.blank()
+
+	w.title("Country Exposure")
+	w.header("Code", "Country", "Market Weight", "Net Assets Weight")
+	for _, country := range overview.Countries {
+		w.row(
+			excelCell{country.CountryCode, styles.text},
+			excelCell{country.CountryName, styles.text},
+			excelCell{country.FundMktPercent, styles.percent},
+			excelCell{country.FundTnaPercent, styles.percent},
+		)
+	}
+	w.blank()
+
+	if holding != nil && len(holding.Stocks) > 0 {
+		w.title("Stock Holdings")
+		w.header("Rank", "Symbol", "Type", "Shares", "Market Value", "Weight")
+		for _, stock := range holding.Stocks {
+			w.row(
+				excelCell{stock.Rank, styles.number},
+				excelCell{stock.Symbol, styles.text},
+				excelCell{stock.Type, styles.text},
+				excelCell{stock.Shares, styles.number},
+				excelCell{stock.MarketValue, styles.price},
+				excelCell{stock.MarketValPercent, styles.percent},
+			)
+		}
+		w.blank()
+	}
+
+	if holding != nil && len(holding.Bonds) > 0 {
+		w.title("Bond Holdings")
+		w.header("Type", "Face Amount", "Rate", "Market Value", "Weight")
+		for _, bond := range holding.Bonds {
+			w.row(
+				excelCell{bond.Type, styles.text},
+				excelCell{bond.FaceAmount, styles.price},
+				excelCell{bond.Rate, styles.percent},
+				excelCell{bond.MarketValue, styles.price},
+				excelCell{bond.MarketValPercent, styles.percent},
+			)
+		}
+		w.blank()
+	}
+
+	if distribution != nil && len(distribution.DistributionHistories) > 0 {
+		w.title("Distribution History")
+		w.header("Type", "Amount", "Ex-Dividend Date", "Record Date", "Payable Date", "Description", "Code")
+		for _, history := range distribution.DistributionHistories {
+			w.row(
+				excelCell{history.Type, styles.text},
+				excelCell{history.DistributionAmount, styles.amount},
+				excelDateCell(history.ExDividendDate, styles),
+				excelDateCell(history.RecordDate, styles),
+				excelDateCell(history.PayableDate, styles),
+				excelCell{history.DistDesc, styles.text},
+				excelCell{history.DistCode, styles.text},
+			)
+		}
+	}
+
+	if err := f.SetColWidth(sheet, "A", "G", 18); err != nil {
+		return err
+	}
+
+	return w.err
+}
+
+// newSheetName creates a valid sheet name from a ticker, e.g. VFV.TO becomes VFV
+func newSheetName(yahooTicker string) string {
+	name := ticker.ParseExchangeTicker(yahooTicker)
+	name = strings.NewReplacer(":", "", "\\", "", "/", "", "?", "", "*", "", "[", "", "]", "").Replace(name)
+
+	if len(name) > maxSheetNameLength {
+		name = name[:maxSheetNameLength]
+	}
+
+	return name
+}
+
+// excelDateCell creates a date cell, a missing date is an empty text cell
+func excelDateCell(date *time.Time, styles *excelStyles) excelCell {
+	if date == nil {
+		return excelCell{"", styles.text}
+	}
+
+	return excelCell{*date, styles.date}
+}
+
+///////////////////////////////////////////////////////////
+// Sheet writer
+///////////////////////////////////////////////////////////
+
+// excelStyles struct holds style ids of the workbook
+type excelStyles struct {
+	title   int
+	header  int
+	text    int
+	price   int
+	amount  int
+	assets  int
+	number  int
+	percent int
+	date    int
+}
+
+func newExcelStyles(f *excelize.File) (*excelStyles, error) {
+	var err error
+	styles := &excelStyles{}
+
+	newStyle := func(style *excelize.Style) int {
+		if err != nil {
+			return 0
+		}
+
+		var id int
+		id, err = f.NewStyle(style)
+		return id
+	}
+
+	numFmt := func(format string) *excelize.Style {
+		return &excelize.Style{CustomNumFmt: &format}
+	}
+
+	styles.title = newStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}})
+	styles.header = newStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#D9D9D9"}},
+	})
+	styles.text = newStyle(numFmt(excelTextFormat))
+	styles.price = newStyle(numFmt(excelPriceFormat))
+	styles.amount = newStyle(numFmt(excelAmountFormat))
+	styles.assets = newStyle(numFmt(excelAssetsFormat))
+	styles.number = newStyle(numFmt(excelNumberFormat))
+	styles.percent = newStyle(numFmt(excelPercentFormat))
+	styles.date = newStyle(numFmt(excelDateFormat))
+
+	return styles, err
+}
+
+// excelCell struct
+type excelCell struct {
+	value interface{}
+	style int
+}
+
+// excelSheetWriter writes rows one after another and keeps the first error
+type excelSheetWriter struct {
+	f      *excelize.File
+	styles *excelStyles
+	sheet  string
+	cursor int
+	err    error
+}
+
+func newExcelSheetWriter(f *excelize.File, styles *excelStyles, sheet string) *excelSheetWriter {
+	return &excelSheetWriter{
+		f:      f,
+		styles: styles,
+		sheet:  sheet,
+		cursor: 1,
+	}
+}
+
+// title writes a bold title row
+func (w *excelSheetWriter) title(title string) {
+	w.write(excelCell{title, w.styles.title})
+}
+
+// header writes a header row
+func (w *excelSheetWriter) header(columns ...string) {
+	var cells []excelCell
+	for _, column := range columns {
+		cells = append(cells, excelCell{column, w.styles.header})
+	}
+
+	w.write(cells...)
+}
+
+// row writes a data row
+func (w *excelSheetWriter) row(cells ...excelCell) {
+	w.write(cells...)
+}
+
+// blank skips a row
+func (w *excelSheetWriter) blank() {
+	w.cursor++
+}
+
+func (w *excelSheetWriter) write(cells ...excelCell) {
+	if w.err != nil {
+		return
+	}
+
+	for i, cell := range cells {
+		axis, err := excelize.CoordinatesToCellName(i+1, w.cursor)
+		if err != nil {
+			w.err = err
+			return
+		}
+
+		value := cell.value
+		switch v := value.(type) {
+		case time.Time:
+			value = v.UTC()
+		case decimal.Decimal:
+			// excel numbers are doubles, a decimal would be written as text
+			value = v.Float64()
+		}
+
+		if err := w.f.SetCellValue(w.sheet, axis, value); err != nil {
+			w.err = err
+			return
+		}
+
+		if err := w.f.SetCellStyle(w.sheet, axis, axis, cell.style); err != nil {
+			w.err = err
+			return
+		}
+	}
+
+	w.cursor++
+}
@@ -0,0 +1,188 @@
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/dividends"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// icsLineLength is the longest line in octets allowed by RFC 5545, longer lines are folded
+const icsLineLength = 75
+
+// icsProductID identifies the producer of the calendar
+const icsProductID = "-//lenoobz//Vanguard Canada ETF Dividend Calendar//EN"
+
+// ICSExporter struct
+type ICSExporter struct {
+	dividendService *dividends.Service
+	outFile         string
+	log             logger.ContextLog
+}
+
+// NewICSExporter create new iCalendar exporter
+func NewICSExporter(dividendService *dividends.Service, outFile string, log logger.ContextLog) *ICSExporter {
+	return &ICSExporter{
+		dividendService: dividendService,
+		outFile:         outFile,
+		log:             log,
+	}
+}
+
+// Export writes an iCalendar feed with ex-dividend and payable dates of all funds between from and to
+func (e *ICSExporter) Export(ctx context.Context, asOf, from, to time.Time) error {
+	calendar, err := e.dividendService.GetDividendCalendar(ctx, asOf, from, to)
+	if err != nil {
+		e.log.Error(ctx, "get dividend calendar failed", "error", err)
+		return err
+	}
+
+	f, err := os.Create(e.outFile)
+	if err != nil {
+		e.log.Error(ctx, "create ics file failed", "file", e.outFile, "error", err)
+		return err
+	}
+	defer f.Close()
+
+	if err := writeICS(f, calendar, time.Now().UTC()); err != nil {
+		e.log.Error(ctx, "write ics file failed", "file", e.outFile, "error", err)
+		return err
+	}
+
+	e.log.Info(ctx, "write ics file", "file", e.outFile, "upcoming", len(calendar.Upcoming), "past", len(calendar.Past))
+	return nil
+}
+
+// writeICS writes the dividend calendar in iCalendar format
+func writeICS(w io.Writer, calendar *entities.DividendCalendar, stamp time.Time) error {
+	iw := &icsWriter{w: bufio.NewWriter(w)}
+
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:" + icsProductID)
+	iw.line("CALSCALE:GREGORIAN")
+	iw.line("METHOD:PUBLISH")
+	iw.line("X-WR-CALNAME:" + escapeICSText("Vanguard Canada ETF Distributions"))
+
+	var events []*entities.DividendEvent
+	events = append(events, calendar.Past...)
+	events = append(events, calendar.Upcoming...)
+
+	for _, event := range events {
+		iw.event(event, "ex", *event.ExDividendDate, "ex-dividend", stamp)
+
+		if event.PayableDate != nil {
+			iw.event(event, "pay", *event.PayableDate, "distribution payable", stamp)
+		}
+	}
+
+	iw.line("END:VCALENDAR")
+
+	if iw.err != nil {
+		return iw.err
+	}
+
+	return iw.w.Flush()
+}
+
+///////////////////////////////////////////////////////////
+// iCalendar writer
+///////////////////////////////////////////////////////////
+
+// icsWriter writes folded CRLF terminated lines and keeps the first error
+type icsWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// event writes an all-day event of a distribution
+func (iw *icsWriter) event(event *entities.DividendEvent, kind string, date time.Time, label string, stamp time.Time) {
+	exchangeTicker := ticker.ParseExchangeTicker(event.Ticker)
+
+	summary := fmt.Sprintf("%s %s", exchangeTicker, label)
+	if !event.Amount.IsZero() {
+		summary = fmt.Sprintf("%s $%s", summary, event.Amount)
+	}
+	if event.Projected {
+		summary += " (projected)"
+	}
+
+	var description []string
+	if event.Name != "" {
+		description = append(description, event.Name)
+	}
+	if event.DistDesc != "" {
+		description = append(description, event.DistDesc)
+	}
+	if event.DividendSchedule != "" {
+		description = append(description, fmt.Sprintf("Schedule: %s", event.DividendSchedule))
+	}
+	if event.Projected {
+		description = append(description, "Projected from the last distribution and the dividend schedule, not announced yet.")
+	}
+
+	iw.line("BEGIN:VEVENT")
+	iw.line(fmt.Sprintf("UID:%s-%s-%s@vanguard-ca-etf", exchangeTicker, kind, date.Format("20060102")))
+	iw.line("DTSTAMP:" + stamp.Format("20060102T150405Z"))
+	iw.line("DTSTART;VALUE=DATE:" + date.Format("20060102"))
+	iw.line("DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format("20060102"))
+	iw.line("SUMMARY:" + escapeICSText(summary))
+	if len(description) > 0 {
+		iw.line("DESCRIPTION:" + escapeICSText(strings.Join(description, "\n")))
+	}
+	iw.line("TRANSP:TRANSPARENT")
+	iw.line("END:VEVENT")
+}
+
+// line writes a content line folded at 75 octets without splitting utf-8 characters
+func (iw *icsWriter) line(content string) {
+	if iw.err != nil {
+		return
+	}
+
+	limit := icsLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(content[cut]) {
+			cut--
+		}
+
+		if _, iw.err = iw.w.WriteString(content[:cut] + "\r\n "); iw.err != nil {
+			return
+		}
+
+		content = content[cut:]
+		// continuation lines start with a space which counts toward the limit
+		limit = icsLineLength - 1
+	}
+
+	_, iw.err = iw.w.WriteString(content + "\r\n")
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// escapeICSText escapes a TEXT value as defined by RFC 5545
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// isUTF8Start checks if a byte starts a utf-8 character
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,82 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+func TestWriteICS(t *testing.T) {
+	exDate := time.Date(2026, 12, 30, 0, 0, 0, 0, time.UTC)
+	payDate := time.Date(2027, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	calendar := &entities.DividendCalendar{
+		Upcoming: []*entities.DividendEvent{{
+			Ticker:           "VFV.TO",
+			Name:             "Vanguard S&P 500 Index ETF; CAD, unhedged",
+			DividendSchedule: "Quarterly",
+			Amount:           decimal.RequireFromString("0.2"),
+			ExDividendDate:   &exDate,
+			PayableDate:      &payDate,
+			Projected:        true,
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeICS(&buf, calendar, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("writeICS error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:VFV-ex-20261230@vanguard-ca-etf\r\n",
+		"DTSTAMP:20261019T080000Z\r\n",
+		"DTSTART;VALUE=DATE:20261230\r\nDTEND;VALUE=DATE:20261231\r\n",
+		"SUMMARY:VFV ex-dividend $0.2 (projected)\r\n",
+		"UID:VFV-pay-20270107@vanguard-ca-etf\r\n",
+		"SUMMARY:VFV distribution payable $0.2 (projected)\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar does not contain %q", want)
+		}
+	}
+
+	if got := strings.Count(out, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("got %d events, want ex-dividend and payable", got)
+	}
+
+	// the long description is escaped and folded into lines of at most 75 octets
+	if unfolded := strings.ReplaceAll(out, "\r\n ", ""); !strings.Contains(unfolded, `DESCRIPTION:Vanguard S&P 500 Index ETF\; CAD\, unhedged\nSchedule: Quarterly`) {
+		t.Errorf("calendar has no escaped description:\n%s", out)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line %q is %d octets long", line, len(line))
+		}
+	}
+}
+
+func TestICSWriterFoldsUTF8(t *testing.T) {
+	var buf bytes.Buffer
+	iw := &icsWriter{w: bufio.NewWriter(&buf)}
+	iw.line("SUMMARY:" + strings.Repeat("é", 40))
+	iw.w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), lines)
+	}
+
+	// 8 octets of name then 33 two octet characters fit, the 34th would split at octet 75
+	if len(lines[0]) != 74 || !strings.HasPrefix(lines[1], " ") {
+		t.Errorf("folded lines = %q, want the cut before a character boundary", lines)
+	}
+}
@@ -0,0 +1,444 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/export"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Parquet dataset names, each one is written to its own folder under the output directory
+const (
+	parquetFundDataset             = "fund_list"
+	parquetOverviewDataset         = "fund_overview"
+	parquetOverviewSectorDataset   = "fund_overview_sector"
+	parquetOverviewCountryDataset  = "fund_overview_country"
+	parquetOverviewDividendDataset = "fund_overview_dividend"
+	parquetStockHoldingDataset     = "fund_holding_stock"
+	parquetBondHoldingDataset      = "fund_holding_bond"
+	parquetDistributionDataset     = "fund_distribution"
+)
+
+// unknownAssetCode is used as partition value when the asset code of a fund cannot be found
+const unknownAssetCode = "UNKNOWN"
+
+// ParquetExporter struct
+type ParquetExporter struct {
+	exportService *export.Service
+	outDir        string
+	log           logger.ContextLog
+}
+
+// NewParquetExporter create new parquet exporter
+func NewParquetExporter(exportService *export.Service, outDir string, log logger.ContextLog) *ParquetExporter {
+	return &ParquetExporter{
+		exportService: exportService,
+		outDir:        outDir,
+		log:           log,
+	}
+}
+
+// Export writes every dataset as parquet files partitioned by scrape date and asset code
+func (e *ParquetExporter) Export(ctx context.Context) error {
+	dataset, err := e.exportService.GetDataset(ctx)
+	if err != nil {
+		e.log.Error(ctx, "get export dataset failed", "error", err)
+		return err
+	}
+
+	for _, table := range newParquetTables(dataset) {
+		if err := e.writeTable(ctx, table); err != nil {
+			e.log.Error(ctx, "write parquet table failed", "table", table.name, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTable writes one parquet file per partition of the table
+func (e *ParquetExporter) writeTable(ctx context.Context, table *parquetTable) error {
+	for _, key := range table.partitionKeys() {
+		dir := filepath.Join(e.outDir, table.name, fmt.Sprintf("scrape_date=%s", key.scrapeDate), fmt.Sprintf("asset_code=%s", key.assetCode))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, "part-00000.parquet")
+		if err := writeParquetFile(path, table.schema, table.rows[key]); err != nil {
+			return err
+		}
+
+		e.log.Info(ctx, "write parquet file", "path", path, "rows", len(table.rows[key]))
+	}
+
+	return nil
+}
+
+// writeParquetFile writes rows to a snappy compressed parquet file
+func writeParquetFile(path string, schema interface{}, rows []interface{}) error {
+	fw, err := local.NewLocalFileWriter(path)
+	if err != nil {
+		return err
+	}
+	defer fw.Close()
+
+	pw, err := writer.NewParquetWriter(fw, schema, 1)
+	if err != nil {
+		return err
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	for _, row := range rows {
+		if err := pw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return pw.WriteStop()
+}
+
+///////////////////////////////////////////////////////////
+// Parquet tables
+///////////////////////////////////////////////////////////
+
+// parquetPartition struct
+type parquetPartition struct {
+	scrapeDate string
+	assetCode  string
+}
+
+// parquetTable struct holds the rows of a dataset grouped by partition
+type parquetTable struct {
+	name   string
+	schema interface{}
+	rows   map[parquetPartition][]interface{}
+}
+
+func newParquetTable(name string, schema interface{}) *parquetTable {
+	return &parquetTable{
+		name:   name,
+		schema: schema,
+		rows:   make(map[parquetPartition][]interface{}),
+	}
+}
+
+// add adds a row to the partition of the given scrape time and asset code
+func (t *parquetTable) add(modifiedAt int64, assetCode string, row interface{}) {
+	if assetCode == "" {
+		assetCode = unknownAssetCode
+	}
+
+	key := parquetPartition{
+		scrapeDate: time.Unix(modifiedAt, 0).UTC().Format("2006-01-02"),
+		assetCode:  strings.ToUpper(assetCode),
+	}
+
+	t.rows[key] = append(t.rows[key], row)
+}
+
+// partitionKeys returns partitions in a stable order
+func (t *parquetTable) partitionKeys() []parquetPartition {
+	var keys []parquetPartition
+	for key := range t.rows {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].scrapeDate != keys[j].scrapeDate {
+			return keys[i].scrapeDate < keys[j].scrapeDate
+		}
+		return keys[i].assetCode < keys[j].assetCode
+	})
+
+	return keys
+}
+
+// newParquetTables flattens stored documents to parquet rows
+func newParquetTables(dataset *export.Dataset) []*parquetTable {
+	assetCodes := dataset.AssetCodes()
+
+	fundTable := newParquetTable(parquetFundDataset, new(parquetFundRow))
+	for _, fund := range dataset.Funds {
+		fundTable.add(fund.ModifiedAt, fund.AssetCode, &parquetFundRow{
+			Ticker:        fund.Ticker,
+			PortID:        fund.PortID,
+			Name:          fund.Name,
+			AssetCode:     fund.AssetCode,
+			Currency:      fund.Currency,
+			IssueType:     fund.IssueType,
+			ProductType:   fund.ProductType,
+			ManagementFee: optionalFloat(fund.ManagementFee),
+			MerFee:        optionalFloat(fund.MerFee),
+			ModifiedAt:    toTimestampMillis(fund.ModifiedAt),
+		})
+	}
+
+	overviewTable := newParquetTable(parquetOverviewDataset, new(parquetOverviewRow))
+	sectorTable := newParquetTable(parquetOverviewSectorDataset, new(parquetSectorRow))
+	countryTable := newParquetTable(parquetOverviewCountryDataset, new(parquetCountryRow))
+	dividendTable := newParquetTable(parquetOverviewDividendDataset, new(parquetDividendRow))
+	for _, overview := range dataset.Overviews {
+		assetCode := assetCodes[overview.Ticker]
+		modifiedAt := toTimestampMillis(overview.ModifiedAt)
+
+		overviewTable.add(overview.ModifiedAt, assetCode, &parquetOverviewRow{
+			Ticker:           overview.Ticker,
+			PortID:           overview.PortID,
+			Name:             overview.Name,
+			ShortName:        overview.ShortName,
+			AssetClass:       overview.AssetClass,
+			Strategy:         overview.Strategy,
+			DividendSchedule: overview.DividendSchedule,
+			Currency:         overview.Currency,
+			Isin:             overview.Isin,
+			Sedol:            overview.Sedol,
+			TotalAssets:      overview.TotalAssets.Float64(),
+			Yield12Month:     overview.Yield12Month.Float64(),
+			Price:            overview.Price.Float64(),
+			ManagementFee:    overview.ManagementFee.Float64(),
+			MerFee:           overview.MerFee.Float64(),
+			DistYield:        overview.DistYield.Float64(),
+			DistAmount:       overview.DistAmount.Float64(),
+			AllocationStock:  overview.AllocationStock.Float64(),
+			AllocationBond:   overview.AllocationBond.Float64(),
+			AllocationCash:   overview.AllocationCash.Float64(),
+			ModifiedAt:       modifiedAt,
+		})
+
+		for _, sector := range overview.Sectors {
+			sectorTable.add(overview.ModifiedAt, assetCode, &parquetSectorRow{
+				Ticker:      overview.Ticker,
+				SectorCode:  sector.SectorCode,
+				SectorName:  sector.SectorName,
+				FundPercent: sector.FundPercent.Float64(),
+				ModifiedAt:  modifiedAt,
+			})
+		}
+
+		for _, country := range overview.Countries {
+			countryTable.add(overview.ModifiedAt, assetCode, &parquetCountryRow{
+				Ticker:          overview.Ticker,
+				CountryCode:     country.CountryCode,
+				CountryName:     country.CountryName,
+				FundMktPercent:  country.FundMktPercent.Float64(),
+				FundTnaPercent:  country.FundTnaPercent.Float64(),
+				HoldingStatCode: country.HoldingStatCode,
+				ModifiedAt:      modifiedAt,
+			})
+		}
+
+		for _, dividend := range overview.Dividends {
+			dividendTable.add(overview.ModifiedAt, assetCode, &parquetDividendRow{
+				Ticker:       overview.Ticker,
+				Amount:       dividend.Amount.Float64(),
+				CurrencyCode: dividend.CurrencyCode,
+				AsOfDate:     toOptionalTimestampMillis(dividend.AsOfDate),
+				ModifiedAt:   modifiedAt,
+			})
+		}
+	}
+
+	stockTable := newParquetTable(parquetStockHoldingDataset, new(parquetStockHoldingRow))
+	bondTable := newParquetTable(parquetBondHoldingDataset, new(parquetBondHoldingRow))
+	for _, holding := range dataset.Holdings {
+		modifiedAt := toTimestampMillis(holding.ModifiedAt)
+
+		for _, stock := range holding.Stocks {
+			stockTable.add(holding.ModifiedAt, holding.AssetCode, &parquetStockHoldingRow{
+				Ticker:           holding.Ticker,
+				Rank:             int32(stock.Rank),
+				Symbol:           stock.Symbol,
+				Type:             stock.Type,
+				Shares:           stock.Shares.Float64(),
+				MarketValue:      stock.MarketValue.Float64(),
+				MarketValPercent: stock.MarketValPercent.Float64(),
+				ModifiedAt:       modifiedAt,
+			})
+		}
+
+		for _, bond := range holding.Bonds {
+			bondTable.add(holding.ModifiedAt, holding.AssetCode, &parquetBondHoldingRow{
+				Ticker:           holding.Ticker,
+				Type:             bond.Type,
+				FaceAmount:       bond.FaceAmount.Float64(),
+				Rate:             bond.Rate.Float64(),
+				MarketValue:      bond.MarketValue.Float64(),
+				MarketValPercent: bond.MarketValPercent.Float64(),
+				ModifiedAt:       modifiedAt,
+			})
+		}
+	}
+
+	distributionTable := newParquetTable(parquetDistributionDataset, new(parquetDistributionRow))
+	for _, distribution := range dataset.Distributions {
+		assetCode := assetCodes[distribution.Ticker]
+		modifiedAt := toTimestampMillis(distribution.ModifiedAt)
+
+		for _, history := range distribution.DistributionHistories {
+			distributionTable.add(distribution.ModifiedAt, assetCode, &parquetDistributionRow{
+				Ticker:             distribution.Ticker,
+				PortID:             distribution.PortID,
+				Type:               history.Type,
+				DistributionAmount: history.DistributionAmount.Float64(),
+				ExDividendDate:     toOptionalTimestampMillis(history.ExDividendDate),
+				RecordDate:         toOptionalTimestampMillis(history.RecordDate),
+				PayableDate:        toOptionalTimestampMillis(history.PayableDate),
+				DistDesc:           history.DistDesc,
+				DistCode:           history.DistCode,
+				ModifiedAt:         modifiedAt,
+			})
+		}
+	}
+
+	return []*parquetTable{
+		fundTable,
+		overviewTable,
+		sectorTable,
+		countryTable,
+		dividendTable,
+		stockTable,
+		bondTable,
+		distributionTable,
+	}
+}
+
+///////////////////////////////////////////////////////////
+// Parquet rows, amounts and weights are DOUBLE columns
+///////////////////////////////////////////////////////////
+
+type parquetFundRow struct {
+	Ticker        string   `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	PortID        string   `parquet:"name=port_id, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Name          string   `parquet:"name=name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	AssetCode     string   `parquet:"name=asset_code, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Currency      string   `parquet:"name=currency, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	IssueType     string   `parquet:"name=issue_type, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ProductType   string   `parquet:"name=product_type, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ManagementFee *float64 `parquet:"name=management_fee, type=DOUBLE, repetitiontype=OPTIONAL"`
+	MerFee        *float64 `parquet:"name=mer_fee, type=DOUBLE, repetitiontype=OPTIONAL"`
+	ModifiedAt    int64    `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+type parquetOverviewRow struct {
+	Ticker           string  `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	PortID           string  `parquet:"name=port_id, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Name             string  `parquet:"name=name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ShortName        string  `parquet:"name=short_name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	AssetClass       string  `parquet:"name=asset_class, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Strategy         string  `parquet:"name=strategy, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	DividendSchedule string  `parquet:"name=dividend_schedule, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Currency         string  `parquet:"name=currency, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Isin             string  `parquet:"name=isin, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Sedol            string  `parquet:"name=sedol, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	TotalAssets      float64 `parquet:"name=total_assets, type=DOUBLE"`
+	Yield12Month     float64 `parquet:"name=yield_12_month, type=DOUBLE"`
+	Price            float64 `parquet:"name=price, type=DOUBLE"`
+	ManagementFee    float64 `parquet:"name=management_fee, type=DOUBLE"`
+	MerFee           float64 `parquet:"name=mer_fee, type=DOUBLE"`
+	DistYield        float64 `parquet:"name=dist_yield, type=DOUBLE"`
+	DistAmount       float64 `parquet:"name=dist_amount, type=DOUBLE"`
+	AllocationStock  float64 `parquet:"name=allocation_stock, type=DOUBLE"`
+	AllocationBond   float64 `parquet:"name=allocation_bond, type=DOUBLE"`
+	AllocationCash   float64 `parquet:"name=allocation_cash, type=DOUBLE"`
+	ModifiedAt       int64   `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+type parquetSectorRow struct {
+	Ticker      string  `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	SectorCode  string  `parquet:"name=sector_code, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	SectorName  string  `parquet:"name=sector_name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	FundPercent float64 `parquet:"name=fund_percent, type=DOUBLE"`
+	ModifiedAt  int64   `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+type parquetCountryRow struct {
+	Ticker          string  `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	CountryCode     string  `parquet:"name=country_code, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	CountryName     string  `parquet:"name=country_name, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	FundMktPercent  float64 `parquet:"name=fund_mkt_percent, type=DOUBLE"`
+	FundTnaPercent  float64 `parquet:"name=fund_tna_percent, type=DOUBLE"`
+	HoldingStatCode string  `parquet:"name=holding_stat_code, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ModifiedAt      int64   `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+type parquetDividendRow struct {
+	Ticker       string  `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Amount       float64 `parquet:"name=amount, type=DOUBLE"`
+	CurrencyCode string  `parquet:"name=currency_code, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	AsOfDate     *int64  `parquet:"name=as_of_date, type=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	ModifiedAt   int64   `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+type parquetStockHoldingRow struct {
+	Ticker           string  `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Rank             int32   `parquet:"name=rank, type=INT32"`
+	Symbol           string  `parquet:"name=symbol, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Type             string  `parquet:"name=type, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Shares           float64 `parquet:"name=shares, type=DOUBLE"`
+	MarketValue      float64 `parquet:"name=market_value, type=DOUBLE"`
+	MarketValPercent float64 `parquet:"name=market_val_percent, type=DOUBLE"`
+	ModifiedAt       int64   `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+type parquetBondHoldingRow struct {
+	Ticker           string  `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Type             string  `parquet:"name=type, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	FaceAmount       float64 `parquet:"name=face_amount, type=DOUBLE"`
+	Rate             float64 `parquet:"name=rate, type=DOUBLE"`
+	MarketValue      float64 `parquet:"name=market_value, type=DOUBLE"`
+	MarketValPercent float64 `parquet:"name=market_val_percent, type=DOUBLE"`
+	ModifiedAt       int64   `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+type parquetDistributionRow struct {
+	Ticker             string  `parquet:"name=ticker, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	PortID             string  `parquet:"name=port_id, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Type               string  `parquet:"name=type, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	DistributionAmount float64 `parquet:"name=distribution_amount, type=DOUBLE"`
+	ExDividendDate     *int64  `parquet:"name=ex_dividend_date, type=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	RecordDate         *int64  `parquet:"name=record_date, type=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	PayableDate        *int64  `parquet:"name=payable_date, type=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	DistDesc           string  `parquet:"name=dist_desc, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	DistCode           string  `parquet:"name=dist_code, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ModifiedAt         int64   `parquet:"name=modified_at, type=TIMESTAMP_MILLIS"`
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// toTimestampMillis converts unix seconds to unix milliseconds
+func toTimestampMillis(unix int64) int64 {
+	return unix * 1000
+}
+
+// toOptionalTimestampMillis converts a time to unix milliseconds
+func toOptionalTimestampMillis(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+
+	millis := t.UnixNano() / int64(time.Millisecond)
+	return &millis
+}
+
+// optionalFloat converts a fee of the fund list, a fee missing from the list becomes null and a 0% fee stays 0
+func optionalFloat(value *decimal.Decimal) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	f := value.Float64()
+	return &f
+}
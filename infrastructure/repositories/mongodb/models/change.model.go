@@ -0,0 +1,233 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/taxonomy"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FundChangeModel struct
+type FundChangeModel struct {
+	ID        *primitive.ObjectID `bson:"_id,omitempty"`
+	CreatedAt int64               `bson:"createdAt,omitempty"`
+	Schema    string              `bson:"schema,omitempty"`
+	Ticker    string              `bson:"ticker,omitempty"`
+	PortID    string              `bson:"portId,omitempty"`
+	Type      string              `bson:"type,omitempty"`
+	Field     string              `bson:"field,omitempty"`
+	OldValue  interface{}         `bson:"oldValue,omitempty"`
+	NewValue  interface{}         `bson:"newValue,omitempty"`
+	Delta     decimal.Decimal     `bson:"delta,omitempty"`
+}
+
+// NewFundChangeModel create a fund change model
+func NewFundChangeModel(ticker, portID, changeType, field string, oldValue, newValue interface{}, schemaVersion string) *FundChangeModel {
+	return &FundChangeModel{
+		CreatedAt: time.Now().UTC().Unix(),
+		Schema:    schemaVersion,
+		Ticker:    ticker,
+		PortID:    portID,
+		Type:      changeType,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+	}
+}
+
+// ToFundChange converts the model to an entity
+func (m *FundChangeModel) ToFundChange() *entities.FundChange {
+	return &entities.FundChange{
+		Ticker:     m.Ticker,
+		PortID:     m.PortID,
+		Type:       m.Type,
+		Field:      m.Field,
+		OldValue:   changeValue(m.OldValue),
+		NewValue:   changeValue(m.NewValue),
+		Delta:      m.Delta,
+		DetectedAt: time.Unix(m.CreatedAt, 0).UTC(),
+	}
+}
+
+// DiffFundModels compares the stored fund with the incoming one, a fund which is not stored yet is a newly listed fund
+// and a soft deleted one is relisted
+func DiffFundModels(stored, incoming *FundModel, schemaVersion string) []*FundChangeModel {
+	if stored == nil {
+		return []*FundChangeModel{
+			NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_FUND_LISTED, "ticker", nil, incoming.Name, schemaVersion),
+		}
+	}
+
+	if stored.Deleted {
+		return []*FundChangeModel{
+			NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_FUND_RELISTED, "ticker", nil, incoming.Name, schemaVersion),
+		}
+	}
+
+	return nil
+}
+
+// DiffFundOverviewModels compares the stored fund overview with the incoming one.
+// Sector and country weights are reported only when they shift by at least the threshold in percentage points.
+// Stored sectors are remapped to the taxonomy version of the incoming ones first, and not compared if they cannot be.
+func DiffFundOverviewModels(stored, incoming *FundOverviewModel, threshold decimal.Decimal, schemaVersion string) []*FundChangeModel {
+	if stored == nil {
+		return nil
+	}
+
+	var changes []*FundChangeModel
+
+	if !stored.MerFee.Equal(incoming.MerFee) {
+		change := NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_MER_FEE_CHANGED, "merFee", stored.MerFee, incoming.MerFee, schemaVersion)
+		change.Delta = incoming.MerFee.Sub(stored.MerFee)
+		changes = append(changes, change)
+	}
+
+	if !stored.ManagementFee.Equal(incoming.ManagementFee) {
+		change := NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_MANAGEMENT_FEE_CHANGED, "managementFee", stored.ManagementFee, incoming.ManagementFee, schemaVersion)
+		change.Delta = incoming.ManagementFee.Sub(stored.ManagementFee)
+		changes = append(changes, change)
+	}
+
+	if !strings.EqualFold(stored.DividendSchedule, incoming.DividendSchedule) {
+		changes = append(changes, NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_DIVIDEND_SCHEDULE_CHANGED, "dividendSchedule", stored.DividendSchedule, incoming.DividendSchedule, schemaVersion))
+	}
+
+	// a regrouping of the taxonomy is no shift of the fund
+	storedSectors, ok := sectorWeights(stored.Sectors, stored.SectorTaxonomy, incoming.SectorTaxonomy)
+	incomingSectors, _ := sectorWeights(incoming.Sectors, incoming.SectorTaxonomy, incoming.SectorTaxonomy)
+
+	if ok {
+		for _, code := range weightShifts(storedSectors, incomingSectors, threshold) {
+			change := NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_SECTOR_WEIGHT_SHIFTED, code, storedSectors[code], incomingSectors[code], schemaVersion)
+			change.Delta = incomingSectors[code].Sub(storedSectors[code])
+			changes = append(changes, change)
+		}
+	}
+
+	storedCountries := make(map[string]decimal.Decimal)
+	for _, country := range stored.Countries {
+		storedCountries[country.CountryCode] = storedCountries[country.CountryCode].Add(country.FundMktPercent)
+	}
+
+	incomingCountries := make(map[string]decimal.Decimal)
+	for _, country := range incoming.Countries {
+		incomingCountries[country.CountryCode] = incomingCountries[country.CountryCode].Add(country.FundMktPercent)
+	}
+
+	for _, code := range weightShifts(storedCountries, incomingCountries, threshold) {
+		change := NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_COUNTRY_WEIGHT_SHIFTED, code, storedCountries[code], incomingCountries[code], schemaVersion)
+		change.Delta = incomingCountries[code].Sub(storedCountries[code])
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// DiffFundDistributionModels reports distribution histories of the incoming fund distribution which are not stored yet
+func DiffFundDistributionModels(stored, incoming *FundDistributionModel, schemaVersion string) []*FundChangeModel {
+	if stored == nil {
+		return nil
+	}
+
+	known := make(map[string]bool)
+	for _, history := range stored.DistributionHistories {
+		known[distributionHistoryKey(history)] = true
+	}
+
+	var changes []*FundChangeModel
+	for _, history := range incoming.DistributionHistories {
+		if known[distributionHistoryKey(history)] {
+			continue
+		}
+
+		change := NewFundChangeModel(incoming.Ticker, incoming.PortID, entities.CHANGE_TYPE_NEW_DISTRIBUTION, datetime.FormatDate(history.ExDividendDate), nil, history.DistributionAmount, schemaVersion)
+		change.Delta = history.DistributionAmount
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// distributionHistoryKey identifies a distribution history by its ex-dividend date, type and code
+func distributionHistoryKey(history *DistributionHistoryModel) string {
+	return strings.Join([]string{datetime.FormatDate(history.ExDividendDate), strings.ToUpper(history.Type), strings.ToUpper(history.DistCode)}, "|")
+}
+
+// sectorWeights sums the weights of sectors by their code under a taxonomy version, remapping them from the
+// version they are classified under. It is false if a sector cannot be remapped
+func sectorWeights(sectors []*SectorBreakdownModel, from, to string) (map[string]decimal.Decimal, bool) {
+	// an overview stored before the taxonomy was versioned is classified under the current one
+	if from == "" {
+		from = taxonomy.CurrentVersion()
+	}
+	if to == "" {
+		to = taxonomy.CurrentVersion()
+	}
+
+	weights := make(map[string]decimal.Decimal)
+	for _, sector := range sectors {
+		if sector == nil {
+			continue
+		}
+
+		code := sector.SectorCode
+		if from != to {
+			classification := taxonomy.Find(from, sector.SectorCode, sector.IndustryGroupCode)
+			if classification == nil {
+				return nil, false
+			}
+
+			remapped, err := taxonomy.Remap(classification, to)
+			if err != nil {
+				return nil, false
+			}
+			code = remapped.Sector.Code
+		}
+
+		weights[code] = weights[code].Add(sector.FundPercent)
+	}
+
+	return weights, true
+}
+
+// weightShifts gets sorted codes whose weight shifted by at least the threshold
+func weightShifts(stored, incoming map[string]decimal.Decimal, threshold decimal.Decimal) []string {
+	codes := make(map[string]bool)
+	for code := range stored {
+		codes[code] = true
+	}
+	for code := range incoming {
+		codes[code] = true
+	}
+
+	var shifted []string
+	for code := range codes {
+		if !incoming[code].Sub(stored[code]).Abs().LessThan(threshold) {
+			shifted = append(shifted, code)
+		}
+	}
+
+	sort.Strings(shifted)
+	return shifted
+}
+
+// changeValue converts a stored Decimal128 value, e.g. an old MER, back to a decimal so it is printed as a number
+func changeValue(value interface{}) interface{} {
+	d128, ok := value.(primitive.Decimal128)
+	if !ok {
+		return value
+	}
+
+	d, err := decimal.NewFromDecimal128(d128)
+	if err != nil {
+		return value
+	}
+
+	return d
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/taxonomy"
+)
+
+func TestDiffFundOverviewModels(t *testing.T) {
+	stored := &FundOverviewModel{
+		Ticker:           "VFV.TO",
+		MerFee:           dec("0.09"),
+		ManagementFee:    dec("0.08"),
+		DividendSchedule: "Quarterly",
+		Sectors:          []*SectorBreakdownModel{{SectorCode: "TEC", FundPercent: dec("28")}, {SectorCode: "FIN", FundPercent: dec("13")}},
+		Countries:        []*CountryBreakdownModel{{CountryCode: "USA", FundMktPercent: dec("100")}},
+	}
+	incoming := &FundOverviewModel{
+		Ticker:           "VFV.TO",
+		MerFee:           dec("0.08"),
+		ManagementFee:    dec("0.08"),
+		DividendSchedule: "QUARTERLY",
+		Sectors:          []*SectorBreakdownModel{{SectorCode: "TEC", FundPercent: dec("30.5")}, {SectorCode: "FIN", FundPercent: dec("12.5")}},
+		Countries:        []*CountryBreakdownModel{{CountryCode: "USA", FundMktPercent: dec("99")}, {CountryCode: "IRL", FundMktPercent: dec("1")}},
+	}
+
+	changes := DiffFundOverviewModels(stored, incoming, dec("1"), "1")
+
+	// the schedule only differs by case and FIN moved by less than the threshold
+	want := []struct {
+		changeType string
+		field      string
+		delta      string
+	}{
+		{entities.CHANGE_TYPE_MER_FEE_CHANGED, "merFee", "-0.01"},
+		{entities.CHANGE_TYPE_SECTOR_WEIGHT_SHIFTED, "TEC", "2.5"},
+		{entities.CHANGE_TYPE_COUNTRY_WEIGHT_SHIFTED, "IRL", "1"},
+		{entities.CHANGE_TYPE_COUNTRY_WEIGHT_SHIFTED, "USA", "-1"},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(changes), len(want))
+	}
+
+	for i, change := range changes {
+		if change.Type != want[i].changeType || change.Field != want[i].field || !change.Delta.Equal(dec(want[i].delta)) {
+			t.Errorf("change %d = %s %s %v, want %s %s %v", i, change.Type, change.Field, change.Delta, want[i].changeType, want[i].field, want[i].delta)
+		}
+	}
+
+	if DiffFundOverviewModels(nil, incoming, dec("1"), "1") != nil {
+		t.Error("a new overview has no change")
+	}
+}
+
+func TestDiffFundOverviewModelsAcrossTaxonomies(t *testing.T) {
+	// media moved from consumer discretionary to communication services in 2018, the fund itself did not move
+	before := []*SectorBreakdownModel{{SectorCode: "CND", IndustryGroupCode: "2540", FundPercent: dec("10")}, {SectorCode: "CND", IndustryGroupCode: "2550", FundPercent: dec("15")}}
+	after := []*SectorBreakdownModel{{SectorCode: "TEL", IndustryGroupCode: "5020", FundPercent: dec("10")}, {SectorCode: "CND", IndustryGroupCode: "2550", FundPercent: dec("15")}}
+
+	tests := []struct {
+		storedTaxonomy string
+		stored         []*SectorBreakdownModel
+		want           []string
+	}{
+		{taxonomy.GICS_2016, before, nil},
+		{taxonomy.GICS_2018, before, []string{"CND", "TEL"}},
+		{"GICS_1999", before, nil},
+	}
+
+	for _, test := range tests {
+		stored := &FundOverviewModel{SectorTaxonomy: test.storedTaxonomy, Sectors: test.stored}
+		incoming := &FundOverviewModel{SectorTaxonomy: taxonomy.GICS_2018, Sectors: after}
+
+		var got []string
+		for _, change := range DiffFundOverviewModels(stored, incoming, dec("1"), "1") {
+			got = append(got, change.Field)
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("sector shifts from %s = %v, want %v", test.storedTaxonomy, got, test.want)
+		}
+	}
+}
+
+func TestDiffFundDistributionModels(t *testing.T) {
+	stored := &FundDistributionModel{
+		DistributionHistories: []*DistributionHistoryModel{{Type: "Income", ExDividendDate: date("2026-09-28"), DistributionAmount: dec("0.2")}},
+	}
+	incoming := &FundDistributionModel{
+		Ticker: "VFV.TO",
+		DistributionHistories: []*DistributionHistoryModel{
+			{Type: "INCOME", ExDividendDate: date("2026-09-28"), DistributionAmount: dec("0.2")},
+			{Type: "Income", ExDividendDate: date("2026-12-29"), DistributionAmount: dec("0.22")},
+		},
+	}
+
+	changes := DiffFundDistributionModels(stored, incoming, "1")
+	if len(changes) != 1 || changes[0].Type != entities.CHANGE_TYPE_NEW_DISTRIBUTION || changes[0].Field != "2026-12-29" || !changes[0].Delta.Equal(dec("0.22")) {
+		t.Errorf("changes = %+v, want the december distribution only", changes)
+	}
+}
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func date(value string) *time.Time {
+	t, _ := datetime.ParseDate(value)
+	return t
+}
@@ -6,6 +6,8 @@ import (
 
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -17,6 +19,7 @@ type FundDistributionModel struct {
 	ModifiedAt            int64                       `bson:"modifiedAt,omitempty"`
 	Enabled               bool                        `bson:"enabled"`
 	Deleted               bool                        `bson:"deleted"`
+	DelistedAt            int64                       `bson:"delistedAt,omitempty"`
 	Schema                string                      `bson:"schema,omitempty"`
 	PortID                string                      `bson:"portId,omitempty"`
 	Ticker                string                      `bson:"ticker,omitempty"`
@@ -25,13 +28,13 @@ type FundDistributionModel struct {
 
 // FundDistribution struct
 type DistributionHistoryModel struct {
-	Type               string  `bson:"type,omitempty"`
-	DistributionAmount float64 `bson:"distributionAmount,omitempty"`
-	ExDividendDate     string  `bson:"exDividendDate,omitempty"`
-	RecordDate         string  `bson:"recordDate,omitempty"`
-	PayableDate        string  `bson:"payableDate,omitempty"`
-	DistDesc           string  `bson:"distDesc,omitempty"`
-	DistCode           string  `bson:"distCode,omitempty"`
+	Type               string          `bson:"type,omitempty"`
+	DistributionAmount decimal.Decimal `bson:"distributionAmount,omitempty"`
+	ExDividendDate     *time.Time      `bson:"exDividendDate,omitempty"`
+	RecordDate         *time.Time      `bson:"recordDate,omitempty"`
+	PayableDate        *time.Time      `bson:"payableDate,omitempty"`
+	DistDesc           string          `bson:"distDesc,omitempty"`
+	DistCode           string          `bson:"distCode,omitempty"`
 }
 
 // NewFundDistributionModel create a fund distribution model
@@ -68,14 +71,58 @@ func NewFundDistributionModel(ctx context.Context, log logger.ContextLog, fundDi
 	return fundDistributionModels, nil
 }
 
+// ToFundDistributionRecord converts the model to an entity
+func (m *FundDistributionModel) ToFundDistributionRecord() *entities.FundDistributionRecord {
+	record := &entities.FundDistributionRecord{
+		ModifiedAt: m.ModifiedAt,
+		PortID:     m.PortID,
+		Ticker:     m.Ticker,
+	}
+
+	for _, history := range m.DistributionHistories {
+		if history == nil {
+			continue
+		}
+
+		record.DistributionHistories = append(record.DistributionHistories, &entities.DistributionHistoryRecord{
+			Type:               history.Type,
+			DistributionAmount: history.DistributionAmount,
+			ExDividendDate:     history.ExDividendDate,
+			RecordDate:         history.RecordDate,
+			PayableDate:        history.PayableDate,
+			DistDesc:           history.DistDesc,
+			DistCode:           history.DistCode,
+		})
+	}
+
+	return record
+}
+
 func newDistributionHistoryModel(ctx context.Context, log logger.ContextLog, distributionHistory *entities.DistributionHistory) (*DistributionHistoryModel, error) {
-	return &DistributionHistoryModel{
+	distributionHistoryModel := &DistributionHistoryModel{
 		Type:               distributionHistory.Type,
 		DistributionAmount: distributionHistory.DistributionAmount,
-		ExDividendDate:     distributionHistory.ExDividendDate,
-		RecordDate:         distributionHistory.RecordDate,
-		PayableDate:        distributionHistory.PayableDate,
 		DistDesc:           distributionHistory.DistDesc,
 		DistCode:           distributionHistory.DistCode,
-	}, nil
+	}
+
+	exDividendDate, err := datetime.ParseDate(distributionHistory.ExDividendDate)
+	if err != nil {
+		log.Warn(ctx, "parse ExDividendDate failed", "error", err, "ExDividendDate", distributionHistory.ExDividendDate)
+	}
+	distributionHistoryModel.ExDividendDate = exDividendDate
+
+	recordDate, err := datetime.ParseDate(distributionHistory.RecordDate)
+	if err != nil {
+		log.Warn(ctx, "parse RecordDate failed", "error", err, "RecordDate", distributionHistory.RecordDate)
+	}
+	distributionHistoryModel.RecordDate = recordDate
+
+	payableDate, err := datetime.ParseDate(distributionHistory.PayableDate)
+	if err != nil {
+		log.Warn(ctx, "parse PayableDate failed", "error", err, "PayableDate", distributionHistory.PayableDate)
+	}
+	distributionHistoryModel.PayableDate = payableDate
+
+	return distributionHistoryModel, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -17,16 +18,18 @@ type FundModel struct {
 	ModifiedAt    int64               `bson:"modifiedAt,omitempty"`
 	Enabled       bool                `bson:"enabled"`
 	Deleted       bool                `bson:"deleted"`
+	DelistedAt    int64               `bson:"delistedAt,omitempty"`
 	Schema        string              `bson:"schema,omitempty"`
 	Ticker        string              `bson:"ticker,omitempty"`
+	Symbology     *SymbologyModel     `bson:"symbology,omitempty"`
 	AssetCode     string              `bson:"assetCode,omitempty"`
 	Name          string              `bson:"name,omitempty"`
 	Currency      string              `bson:"currency,omitempty"`
 	IssueType     string              `bson:"issueType,omitempty"`
 	PortID        string              `bson:"portId,omitempty"`
 	ProductType   string              `bson:"productType,omitempty"`
-	ManagementFee string              `bson:"managementFee,omitempty"`
-	MerFee        string              `bson:"merFee,omitempty"`
+	ManagementFee decimal.NullDecimal `bson:"managementFee,omitempty"`
+	MerFee        decimal.NullDecimal `bson:"merFee,omitempty"`
 }
 
 // NewFundModel create Vanguard fund model
@@ -40,6 +43,7 @@ func NewFundModel(ctx context.Context, log logger.ContextLog, vanguardFund *enti
 
 	if vanguardFund.Ticker != "" {
 		fundModel.Ticker = ticker.GenYahooTickerFromVanguardTicker(vanguardFund.Ticker)
+		fundModel.Symbology = NewSymbologyModel(vanguardFund.Ticker, "", "")
 	}
 
 	if vanguardFund.Name != "" {
@@ -67,12 +71,41 @@ func NewFundModel(ctx context.Context, log logger.ContextLog, vanguardFund *enti
 	}
 
 	if vanguardFund.ManagementFee != "" {
-		fundModel.ManagementFee = vanguardFund.ManagementFee
+		managementFee, err := decimal.NewFromString(vanguardFund.ManagementFee)
+
+		if err != nil {
+			log.Warn(ctx, "parse Fund.ManagementFee failed", "error", err, "ManagementFee", vanguardFund.ManagementFee)
+		} else {
+			fundModel.ManagementFee = decimal.NewNullDecimal(managementFee)
+		}
 	}
 
 	if vanguardFund.MerFee != "" {
-		fundModel.MerFee = vanguardFund.MerFee
+		merFee, err := decimal.NewFromString(vanguardFund.MerFee)
+
+		if err != nil {
+			log.Warn(ctx, "parse Fund.MerValue failed", "error", err, "MerValue", vanguardFund.MerFee)
+		} else {
+			fundModel.MerFee = decimal.NewNullDecimal(merFee)
+		}
 	}
 
 	return fundModel, nil
 }
+
+// ToFundRecord converts the model to an entity
+func (m *FundModel) ToFundRecord() *entities.FundRecord {
+	return &entities.FundRecord{
+		ModifiedAt:    m.ModifiedAt,
+		Ticker:        m.Ticker,
+		Symbology:     m.Symbology.ToSymbology(),
+		AssetCode:     m.AssetCode,
+		Name:          m.Name,
+		Currency:      m.Currency,
+		IssueType:     m.IssueType,
+		PortID:        m.PortID,
+		ProductType:   m.ProductType,
+		ManagementFee: m.ManagementFee.Ptr(),
+		MerFee:        m.MerFee.Ptr(),
+	}
+}
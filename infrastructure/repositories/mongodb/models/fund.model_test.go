@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+func TestFundModelFees(t *testing.T) {
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	// a 0% fee is a fee, a missing or unparsable one is not
+	model, err := NewFundModel(context.Background(), log, &entities.Fund{Ticker: "VFV", MerFee: "0", ManagementFee: "n/a"}, "1")
+	if err != nil {
+		t.Fatalf("NewFundModel error = %v", err)
+	}
+
+	raw, err := bson.Marshal(model)
+	if err != nil {
+		t.Fatalf("marshal fund model failed: %v", err)
+	}
+
+	if got := bson.Raw(raw).Lookup("merFee").Type; got != bsontype.Decimal128 {
+		t.Errorf("stored merFee type = %s, want decimal", got)
+	}
+
+	if _, err := bson.Raw(raw).LookupErr("managementFee"); err == nil {
+		t.Error("stored an unparsable managementFee")
+	}
+
+	var stored FundModel
+	if err := bson.Unmarshal(raw, &stored); err != nil {
+		t.Fatalf("unmarshal fund model failed: %v", err)
+	}
+
+	record := stored.ToFundRecord()
+	if record.MerFee == nil || !record.MerFee.IsZero() || record.ManagementFee != nil {
+		t.Errorf("fees = %v and %v, want 0 and none", record.MerFee, record.ManagementFee)
+	}
+}
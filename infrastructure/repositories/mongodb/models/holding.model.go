@@ -2,12 +2,14 @@ package models
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -19,6 +21,7 @@ type FundHoldingModel struct {
 	ModifiedAt int64                     `bson:"modifiedAt,omitempty"`
 	Enabled    bool                      `bson:"enabled"`
 	Deleted    bool                      `bson:"deleted"`
+	DelistedAt int64                     `bson:"delistedAt,omitempty"`
 	Schema     string                    `bson:"schema,omitempty"`
 	PortID     string                    `bson:"portId,omitempty"`
 	Ticker     string                    `bson:"ticker,omitempty"`
@@ -29,20 +32,21 @@ type FundHoldingModel struct {
 
 // SectorWeightBondModel struct
 type SectorWeightBondModel struct {
-	FaceAmount       float64 `bson:"faceAmount,omitempty"`
-	MarketValPercent float64 `bson:"marketValPercent,omitempty"`
-	MarketValue      float64 `bson:"marketValue,omitempty"`
-	Rate             float64 `bson:"rate,omitempty"`
-	Type             string  `bson:"type,omitempty"`
+	FaceAmount       decimal.Decimal `bson:"faceAmount,omitempty"`
+	MarketValPercent decimal.Decimal `bson:"marketValPercent,omitempty"`
+	MarketValue      decimal.Decimal `bson:"marketValue,omitempty"`
+	Rate             decimal.Decimal `bson:"rate,omitempty"`
+	Type             string          `bson:"type,omitempty"`
 }
 
 // SectorWeightStockModel struct
 type SectorWeightStockModel struct {
-	MarketValPercent float64 `bson:"marketValPercent,omitempty"`
-	MarketValue      float64 `bson:"marketValue,omitempty"`
-	Shares           float64 `bson:"shares,omitempty"`
-	Symbol           string  `bson:"symbol,omitempty"`
-	Type             string  `bson:"type,omitempty"`
+	MarketValPercent decimal.Decimal `bson:"marketValPercent,omitempty"`
+	MarketValue      decimal.Decimal `bson:"marketValue,omitempty"`
+	Shares           decimal.Decimal `bson:"shares,omitempty"`
+	Symbol           string          `bson:"symbol,omitempty"`
+	Type             string          `bson:"type,omitempty"`
+	Rank             int             `bson:"rank,omitempty"` // position by weight in the fund, the largest is 1
 }
 
 // NewFundHoldingModel create a fund holding model
@@ -62,6 +66,47 @@ func NewFundHoldingModel(ctx context.Context, log logger.ContextLog, fundHolding
 	return nil, nil
 }
 
+// ToFundHoldingRecord converts the model to an entity
+func (m *FundHoldingModel) ToFundHoldingRecord() *entities.FundHoldingRecord {
+	record := &entities.FundHoldingRecord{
+		ModifiedAt: m.ModifiedAt,
+		PortID:     m.PortID,
+		Ticker:     m.Ticker,
+		AssetCode:  m.AssetCode,
+	}
+
+	for _, bond := range m.Bonds {
+		if bond == nil {
+			continue
+		}
+
+		record.Bonds = append(record.Bonds, &entities.SectorWeightBondRecord{
+			FaceAmount:       bond.FaceAmount,
+			MarketValPercent: bond.MarketValPercent,
+			MarketValue:      bond.MarketValue,
+			Rate:             bond.Rate,
+			Type:             bond.Type,
+		})
+	}
+
+	for _, stock := range m.Stocks {
+		if stock == nil {
+			continue
+		}
+
+		record.Stocks = append(record.Stocks, &entities.SectorWeightStockRecord{
+			MarketValPercent: stock.MarketValPercent,
+			MarketValue:      stock.MarketValue,
+			Shares:           stock.Shares,
+			Symbol:           stock.Symbol,
+			Type:             stock.Type,
+			Rank:             stock.Rank,
+		})
+	}
+
+	return record
+}
+
 func newBondHolding(ctx context.Context, log logger.ContextLog, fundHolding *entities.FundHolding, schemaVersion string) (*FundHoldingModel, error) {
 	var fundHoldingModel = &FundHoldingModel{
 		ModifiedAt: time.Now().UTC().Unix(),
@@ -130,7 +175,7 @@ func newEquityHolding(ctx context.Context, log logger.ContextLog, fundHolding *e
 			sectorWeightStockModels = append(sectorWeightStockModels, sectorWeightStockModel)
 		}
 
-		fundHoldingModel.Stocks = sectorWeightStockModels
+		fundHoldingModel.Stocks = RankStocks(sectorWeightStockModels)
 	}
 
 	return fundHoldingModel, nil
@@ -178,7 +223,7 @@ func newBalanceHolding(ctx context.Context, log logger.ContextLog, fundHolding *
 		}
 
 		fundHoldingModel.Bonds = sectorWeightBondModels
-		fundHoldingModel.Stocks = sectorWeightStockModels
+		fundHoldingModel.Stocks = RankStocks(sectorWeightStockModels)
 	}
 
 	return fundHoldingModel, nil
@@ -187,25 +232,9 @@ func newBalanceHolding(ctx context.Context, log logger.ContextLog, fundHolding *
 func newSectorWeightBondModel(ctx context.Context, log logger.ContextLog, sectorWeightBond *entities.SectorWeightBond) (*SectorWeightBondModel, error) {
 	var sectorWeightBondModel = &SectorWeightBondModel{}
 
-	if sectorWeightBond.MarketValPercent != "" {
-		marketValPercent, err := sectorWeightBond.MarketValPercent.Float64()
-		if err != nil {
-			log.Warn(ctx, "parse SectorWeightBond.MarketValPercent failed", "error", err, "MarketValPercent", sectorWeightBond.MarketValPercent)
-			marketValPercent = 0
-		}
-
-		sectorWeightBondModel.MarketValPercent = marketValPercent
-	}
-
-	if sectorWeightBond.MarketValue != "" {
-		marketValue, err := sectorWeightBond.MarketValue.Float64()
-		if err != nil {
-			log.Warn(ctx, "parse SectorWeightBond.MarketValue failed", "error", err, "MarketValue", sectorWeightBond.MarketValue)
-			marketValue = 0
-		}
+	sectorWeightBondModel.MarketValPercent = sectorWeightBond.MarketValPercent
 
-		sectorWeightBondModel.MarketValue = marketValue
-	}
+	sectorWeightBondModel.MarketValue = sectorWeightBond.MarketValue
 
 	if sectorWeightBond.Type != "" {
 		sectorWeightBondModel.Type = sectorWeightBond.Type
@@ -225,25 +254,9 @@ func newSectorWeightStockModel(ctx context.Context, log logger.ContextLog, secto
 		sectorWeightStockModel.Symbol = sectorWeightStock.Symbol
 	}
 
-	if sectorWeightStock.MarketValPercent != "" {
-		marketValPercent, err := sectorWeightStock.MarketValPercent.Float64()
-		if err != nil {
-			log.Warn(ctx, "parse SectorWeightStock.MarketValPercent failed", "error", err, "MarketValPercent", sectorWeightStock.MarketValPercent)
-			marketValPercent = 0
-		}
+	sectorWeightStockModel.MarketValPercent = sectorWeightStock.MarketValPercent
 
-		sectorWeightStockModel.MarketValPercent = marketValPercent
-	}
-
-	if sectorWeightStock.MarketValue != "" {
-		marketValue, err := sectorWeightStock.MarketValue.Float64()
-		if err != nil {
-			log.Warn(ctx, "parse SectorWeightStock.MarketValue failed", "error", err, "MarketValue", sectorWeightStock.MarketValue)
-			marketValue = 0
-		}
-
-		sectorWeightStockModel.MarketValue = marketValue
-	}
+	sectorWeightStockModel.MarketValue = sectorWeightStock.MarketValue
 
 	if sectorWeightStock.Type != "" {
 		sectorWeightStockModel.Type = sectorWeightStock.Type
@@ -253,3 +266,29 @@ func newSectorWeightStockModel(ctx context.Context, log logger.ContextLog, secto
 
 	return sectorWeightStockModel, nil
 }
+
+// RankStocks sorts stocks by weight, the largest first, and sets their rank. The weight is the market value percent,
+// or the market value if no percent is published. Stocks of the same weight keep their scraped order.
+func RankStocks(stocks []*SectorWeightStockModel) []*SectorWeightStockModel {
+	var totalPercent decimal.Decimal
+	for _, stock := range stocks {
+		totalPercent = totalPercent.Add(stock.MarketValPercent)
+	}
+
+	weightOf := func(stock *SectorWeightStockModel) decimal.Decimal {
+		if totalPercent.IsZero() {
+			return stock.MarketValue
+		}
+		return stock.MarketValPercent
+	}
+
+	sort.SliceStable(stocks, func(i, j int) bool {
+		return weightOf(stocks[i]).GreaterThan(weightOf(stocks[j]))
+	})
+
+	for i, stock := range stocks {
+		stock.Rank = i + 1
+	}
+
+	return stocks
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MigrationModel struct is an entry of the migrations-applied ledger
+type MigrationModel struct {
+	ID          *primitive.ObjectID `bson:"_id,omitempty"`
+	CreatedAt   int64               `bson:"createdAt,omitempty"`
+	Collection  string              `bson:"collection,omitempty"`
+	Direction   string              `bson:"direction,omitempty"`
+	FromVersion string              `bson:"fromVersion,omitempty"`
+	ToVersion   string              `bson:"toVersion,omitempty"`
+	Description string              `bson:"description,omitempty"`
+	Documents   int                 `bson:"documents"`
+}
+
+// NewMigrationModel create a migration ledger model
+func NewMigrationModel(result *entities.MigrationResult) *MigrationModel {
+	return &MigrationModel{
+		CreatedAt:   time.Now().UTC().Unix(),
+		Collection:  result.Collection,
+		Direction:   result.Direction,
+		FromVersion: result.FromVersion,
+		ToVersion:   result.ToVersion,
+		Description: result.Description,
+		Documents:   result.Documents,
+	}
+}
+
+// ToMigrationResult converts the model to an entity
+func (m *MigrationModel) ToMigrationResult() *entities.MigrationResult {
+	appliedAt := time.Unix(m.CreatedAt, 0).UTC()
+
+	return &entities.MigrationResult{
+		Collection:  m.Collection,
+		Direction:   m.Direction,
+		FromVersion: m.FromVersion,
+		ToVersion:   m.ToVersion,
+		Description: m.Description,
+		Documents:   m.Documents,
+		AppliedAt:   &appliedAt,
+	}
+}
@@ -3,7 +3,7 @@ package models
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +11,10 @@ import (
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/identifier"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/lookup"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/taxonomy"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -22,6 +26,7 @@ type FundOverviewModel struct {
 	ModifiedAt       int64                    `bson:"modifiedAt,omitempty"`
 	Enabled          bool                     `bson:"enabled"`
 	Deleted          bool                     `bson:"deleted"`
+	DelistedAt       int64                    `bson:"delistedAt,omitempty"`
 	Schema           string                   `bson:"schema,omitempty"`
 	PortID           string                   `bson:"portId,omitempty"`
 	AssetClass       string                   `bson:"assetClass,omitempty"`
@@ -32,43 +37,56 @@ type FundOverviewModel struct {
 	Currency         string                   `bson:"currency,omitempty"`
 	Isin             string                   `bson:"isin,omitempty"`
 	Sedol            string                   `bson:"sedol,omitempty"`
+	Cusip            string                   `bson:"cusip,omitempty"`
 	Ticker           string                   `bson:"ticker,omitempty"`
-	TotalAssets      float64                  `bson:"totalAssets,omitempty"`
-	Yield12Month     float64                  `bson:"yield12Month,omitempty"`
-	Price            float64                  `bson:"price,omitempty"`
-	ManagementFee    float64                  `bson:"managementFee,omitempty"`
-	MerFee           float64                  `bson:"merFee,omitempty"`
-	DistYield        float64                  `bson:"distYield,omitempty"`
-	DistAmount       float64                  `bson:"distAmount,omitempty"`
-	AllocationStock  float64                  `bson:"allocationStock,omitempty"`
-	AllocationBond   float64                  `bson:"allocationBond,omitempty"`
-	AllocationCash   float64                  `bson:"allocationCash,omitempty"`
+	Symbology        *SymbologyModel          `bson:"symbology,omitempty"`
+	TotalAssets      decimal.Decimal          `bson:"totalAssets,omitempty"`
+	Yield12Month     decimal.Decimal          `bson:"yield12Month,omitempty"`
+	Price            decimal.Decimal          `bson:"price,omitempty"`
+	ManagementFee    decimal.Decimal          `bson:"managementFee,omitempty"`
+	MerFee           decimal.Decimal          `bson:"merFee,omitempty"`
+	DistYield        decimal.Decimal          `bson:"distYield,omitempty"`
+	DistAmount       decimal.Decimal          `bson:"distAmount,omitempty"`
+	AllocationStock  decimal.Decimal          `bson:"allocationStock,omitempty"`
+	AllocationBond   decimal.Decimal          `bson:"allocationBond,omitempty"`
+	AllocationCash   decimal.Decimal          `bson:"allocationCash,omitempty"`
+	SectorTaxonomy   string                   `bson:"sectorTaxonomy,omitempty"`
 	Sectors          []*SectorBreakdownModel  `bson:"sectors,omitempty"`
 	Countries        []*CountryBreakdownModel `bson:"countries,omitempty"`
+	Regions          []*CountryGroupModel     `bson:"regions,omitempty"`
+	Markets          []*CountryGroupModel     `bson:"markets,omitempty"`
 	Dividends        []*DividendHistoryModel  `bson:"dividends,omitempty"`
 }
 
 // SectorBreakdownModel struct
 type SectorBreakdownModel struct {
-	SectorCode  string  `bson:"sectorCode,omitempty"`
-	SectorName  string  `bson:"sectorName,omitempty"`
-	FundPercent float64 `bson:"fundPercent,omitempty"`
+	SectorCode        string          `bson:"sectorCode,omitempty"`
+	IndustryGroupCode string          `bson:"industryGroupCode,omitempty"`
+	SectorName        string          `bson:"sectorName,omitempty"`
+	FundPercent       decimal.Decimal `bson:"fundPercent,omitempty"`
 }
 
 // CountryBreakdownModel struct
 type CountryBreakdownModel struct {
-	CountryCode     string  `bson:"countryCode,omitempty"`
-	CountryName     string  `bson:"countryName,omitempty"`
-	FundMktPercent  float64 `bson:"fundMktPercent,omitempty"`
-	FundTnaPercent  float64 `bson:"fundTnaPercent,omitempty"`
-	HoldingStatCode string  `bson:"holdingStatCode,omitempty"`
+	CountryCode     string          `bson:"countryCode,omitempty"`
+	CountryName     string          `bson:"countryName,omitempty"`
+	FundMktPercent  decimal.Decimal `bson:"fundMktPercent,omitempty"`
+	FundTnaPercent  decimal.Decimal `bson:"fundTnaPercent,omitempty"`
+	HoldingStatCode string          `bson:"holdingStatCode,omitempty"`
+}
+
+// CountryGroupModel struct is the country exposure rolled up to a region or a market classification
+type CountryGroupModel struct {
+	GroupCode      string          `bson:"groupCode,omitempty"`
+	FundMktPercent decimal.Decimal `bson:"fundMktPercent,omitempty"`
+	FundTnaPercent decimal.Decimal `bson:"fundTnaPercent,omitempty"`
 }
 
 // DividendHistoryModel struct
 type DividendHistoryModel struct {
-	Amount       float64    `bson:"amount,omitempty"`
-	CurrencyCode string     `bson:"currencyCode,omitempty"`
-	AsOfDate     *time.Time `bson:"asOfDate,omitempty"`
+	Amount       decimal.Decimal `bson:"amount,omitempty"`
+	CurrencyCode string          `bson:"currencyCode,omitempty"`
+	AsOfDate     *time.Time      `bson:"asOfDate,omitempty"`
 }
 
 // NewOverviewModel create a fund overview model
@@ -109,82 +127,87 @@ func NewOverviewModel(ctx context.Context, log logger.ContextLog, fundOverview *
 	}
 
 	if fundOverview.FundCode != nil {
+		// keep identifiers with a bad check digit as scraped, the validation rules decide whether they are stored
 		if fundOverview.FundCode.Isin != "" {
-			fundOverviewModel.Isin = fundOverview.FundCode.Isin
+			fundOverviewModel.Isin = identifier.Normalize(fundOverview.FundCode.Isin)
+			fundOverviewModel.Cusip = identifier.GetCusipFromIsin(fundOverview.FundCode.Isin)
 		}
 
 		if fundOverview.FundCode.Sedol != "" {
-			fundOverviewModel.Sedol = fundOverview.FundCode.Sedol
+			fundOverviewModel.Sedol = identifier.Normalize(fundOverview.FundCode.Sedol)
 		}
 
 		if fundOverview.FundCode.ExchangeTicker != "" {
 			fundOverviewModel.Ticker = ticker.GenYahooTickerFromVanguardTicker(fundOverview.FundCode.ExchangeTicker)
+			fundOverviewModel.Symbology = NewSymbologyModel(fundOverview.FundCode.ExchangeTicker, fundOverview.FundCode.Isin, fundOverview.FundCode.Sedol)
 		}
 	}
 
 	if fundOverview.TotalAssets != "" {
-		totalAssets, err := strconv.ParseFloat(fundOverview.TotalAssets, 64)
+		totalAssets, err := decimal.NewFromString(fundOverview.TotalAssets)
 
 		if err != nil {
 			log.Warn(ctx, "parse Overview.TotalAssets failed", "error", err, "TotalAssets", fundOverview.TotalAssets)
-			totalAssets = 0
+			totalAssets = decimal.Zero
 		}
 
 		fundOverviewModel.TotalAssets = totalAssets
 	}
 
 	if fundOverview.Yield12Month != "" {
-		yield12Month, err := strconv.ParseFloat(fundOverview.Yield12Month, 64)
+		yield12Month, err := decimal.NewFromString(fundOverview.Yield12Month)
 
 		if err != nil {
 			log.Warn(ctx, "parse Overview.Yield12Month failed", "error", err, "Yield12Month", fundOverview.Yield12Month)
-			yield12Month = 0
+			yield12Month = decimal.Zero
 		}
 
 		fundOverviewModel.Yield12Month = yield12Month
 	}
 
-	fundOverviewModel.Price = fundOverview.Price
+	if fundOverview.Price.Valid {
+		fundOverviewModel.Price = fundOverview.Price.Decimal
+	}
 
 	if fundOverview.ManagementFee != "" {
-		managementFee, err := strconv.ParseFloat(fundOverview.ManagementFee, 64)
+		managementFee, err := decimal.NewFromString(fundOverview.ManagementFee)
 
 		if err != nil {
 			log.Warn(ctx, "parse Overview.ManagementFee failed", "error", err, "ManagementFee", fundOverview.ManagementFee)
-			managementFee = 0
+			managementFee = decimal.Zero
 		}
 
 		fundOverviewModel.ManagementFee = managementFee
 	}
 
 	if fundOverview.MerFee != "" {
-		merFee, err := strconv.ParseFloat(fundOverview.MerFee, 64)
+		merFee, err := decimal.NewFromString(fundOverview.MerFee)
 
 		if err != nil {
 			log.Warn(ctx, "parse Overview.MerValue failed", "error", err, "MerValue", fundOverview.MerFee)
-			merFee = 0
+			merFee = decimal.Zero
 		}
 
 		fundOverviewModel.MerFee = merFee
 	}
 
 	if fundOverview.DistYield != "" {
-		distYield, err := strconv.ParseFloat(fundOverview.DistYield, 64)
+		distYield, err := decimal.NewFromString(fundOverview.DistYield)
 
 		if err != nil {
 			log.Warn(ctx, "parse Overview.DistYield failed", "error", err, "DistYield", fundOverview.DistYield)
-			distYield = 0
+			distYield = decimal.Zero
 		}
 
 		fundOverviewModel.DistYield = distYield
 	}
 
 	if fundOverview.DistAmount != "" {
-		distAmount, err := strconv.ParseFloat(fundOverview.DistAmount, 64)
+		distAmount, err := decimal.NewFromString(fundOverview.DistAmount)
 
 		if err != nil {
 			log.Warn(ctx, "parse Overview.DistAmount failed", "error", err, "DistAmount", fundOverview.DistAmount)
-			distAmount = 0
+			distAmount = decimal.Zero
 		}
 
 		fundOverviewModel.DistAmount = distAmount
@@ -194,10 +217,12 @@ func NewOverviewModel(ctx context.Context, log logger.ContextLog, fundOverview *
 	fundOverviewModel.AllocationBond = fundOverview.AllocationBond
 	fundOverviewModel.AllocationCash = fundOverview.AllocationCash
 
-	// map sector breakdown model
+	// map sector breakdown model, sector names are classified under the taxonomy in effect today
+	fundOverviewModel.SectorTaxonomy = taxonomy.VersionAt(datetime.Today())
+
 	var sectorModels []*SectorBreakdownModel
 	for _, sector := range fundOverview.Sectors {
-		sectorModel, err := newSectorBreakdownModel(ctx, log, sector)
+		sectorModel, err := newSectorBreakdownModel(ctx, log, sector, fundOverviewModel.SectorTaxonomy)
 
 		if err != nil {
 			return nil, err
@@ -223,6 +248,8 @@ func NewOverviewModel(ctx context.Context, log logger.ContextLog, fundOverview *
 		}
 	}
 	fundOverviewModel.Countries = countryModels
+	fundOverviewModel.Regions = NewRegionModels(countryModels)
+	fundOverviewModel.Markets = NewMarketModels(countryModels)
 
 	// map dividend history model
 	var divHistoryModels []*DividendHistoryModel
@@ -242,46 +269,194 @@ func NewOverviewModel(ctx context.Context, log logger.ContextLog, fundOverview *
 	return fundOverviewModel, nil
 }
 
+// ToFundOverviewRecord converts the model to an entity, the symbology of an overview stored before it was added is
+// generated from its ticker
+func (m *FundOverviewModel) ToFundOverviewRecord() *entities.FundOverviewRecord {
+	record := &entities.FundOverviewRecord{
+		ModifiedAt:       m.ModifiedAt,
+		PortID:           m.PortID,
+		AssetClass:       m.AssetClass,
+		Strategy:         m.Strategy,
+		DividendSchedule: m.DividendSchedule,
+		Name:             m.Name,
+		ShortName:        m.ShortName,
+		Currency:         m.Currency,
+		Isin:             m.Isin,
+		Sedol:            m.Sedol,
+		Cusip:            m.Cusip,
+		Ticker:           m.Ticker,
+		Symbology:        m.Symbology.ToSymbology(),
+		TotalAssets:      m.TotalAssets,
+		Yield12Month:     m.Yield12Month,
+		Price:            m.Price,
+		ManagementFee:    m.ManagementFee,
+		MerFee:           m.MerFee,
+		DistYield:        m.DistYield,
+		DistAmount:       m.DistAmount,
+		AllocationStock:  m.AllocationStock,
+		AllocationBond:   m.AllocationBond,
+		AllocationCash:   m.AllocationCash,
+		SectorTaxonomy:   m.SectorTaxonomy,
+		Regions:          toCountryGroupRecords(m.Regions),
+		Markets:          toCountryGroupRecords(m.Markets),
+	}
+
+	if record.Symbology == nil {
+		record.Symbology = NewSymbologyModel(ticker.ParseExchangeTicker(m.Ticker), m.Isin, m.Sedol).ToSymbology()
+	}
+
+	for _, sector := range m.Sectors {
+		if sector == nil {
+			continue
+		}
+
+		record.Sectors = append(record.Sectors, &entities.SectorBreakdownRecord{
+			SectorCode:        sector.SectorCode,
+			IndustryGroupCode: sector.IndustryGroupCode,
+			SectorName:        sector.SectorName,
+			FundPercent:       sector.FundPercent,
+		})
+	}
+
+	for _, country := range m.Countries {
+		if country == nil {
+			continue
+		}
+
+		record.Countries = append(record.Countries, &entities.CountryBreakdownRecord{
+			CountryCode:     country.CountryCode,
+			CountryName:     country.CountryName,
+			FundMktPercent:  country.FundMktPercent,
+			FundTnaPercent:  country.FundTnaPercent,
+			HoldingStatCode: country.HoldingStatCode,
+		})
+	}
+
+	for _, dividend := range m.Dividends {
+		if dividend == nil {
+			continue
+		}
+
+		record.Dividends = append(record.Dividends, &entities.DividendHistoryRecord{
+			Amount:       dividend.Amount,
+			CurrencyCode: dividend.CurrencyCode,
+			AsOfDate:     dividend.AsOfDate,
+		})
+	}
+
+	return record
+}
+
+// toCountryGroupRecords converts country group models to entities
+func toCountryGroupRecords(groups []*CountryGroupModel) []*entities.CountryGroupRecord {
+	var records []*entities.CountryGroupRecord
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		records = append(records, &entities.CountryGroupRecord{
+			GroupCode:      group.GroupCode,
+			FundMktPercent: group.FundMktPercent,
+			FundTnaPercent: group.FundTnaPercent,
+		})
+	}
+
+	return records
+}
+
+// NewRegionModels rolls a country exposure up to regions
+func NewRegionModels(countries []*CountryBreakdownModel) []*CountryGroupModel {
+	return newCountryGroupModels(countries, func(country *lookup.Country) string {
+		if country == nil {
+			return consts.REGION_OTHER
+		}
+		return country.Region
+	})
+}
+
+// NewMarketModels rolls a country exposure up to developed, emerging and frontier markets
+func NewMarketModels(countries []*CountryBreakdownModel) []*CountryGroupModel {
+	return newCountryGroupModels(countries, func(country *lookup.Country) string {
+		if country == nil {
+			return consts.MARKET_UNCLASSIFIED
+		}
+		return country.Market
+	})
+}
+
+// newCountryGroupModels sums a country exposure by group sorted by fund market percent, heaviest first
+func newCountryGroupModels(countries []*CountryBreakdownModel, groupOf func(country *lookup.Country) string) []*CountryGroupModel {
+	var groupModels []*CountryGroupModel
+	groups := make(map[string]*CountryGroupModel)
+
+	for _, country := range countries {
+		if country == nil {
+			continue
+		}
+
+		// the country code is an alpha-3 code, or OTH which is no country
+		code := groupOf(lookup.FindCountry(country.CountryCode))
+
+		group, ok := groups[code]
+		if !ok {
+			group = &CountryGroupModel{GroupCode: code}
+			groups[code] = group
+			groupModels = append(groupModels, group)
+		}
+
+		group.FundMktPercent = group.FundMktPercent.Add(country.FundMktPercent)
+		group.FundTnaPercent = group.FundTnaPercent.Add(country.FundTnaPercent)
+	}
+
+	sort.SliceStable(groupModels, func(i, j int) bool {
+		return groupModels[i].FundMktPercent.GreaterThan(groupModels[j].FundMktPercent)
+	})
+
+	return groupModels
+}
+
 // newSectorBreakdownModel create sector breakdown model
-func newSectorBreakdownModel(ctx context.Context, log logger.ContextLog, sectorBreakdown *entities.SectorBreakdown) (*SectorBreakdownModel, error) {
+func newSectorBreakdownModel(ctx context.Context, log logger.ContextLog, sectorBreakdown *entities.SectorBreakdown, sectorTaxonomy string) (*SectorBreakdownModel, error) {
 	var sectorBreakdownModel = &SectorBreakdownModel{}
 
 	if sectorBreakdown.SectorName != "" {
 		sectorBreakdownModel.SectorName = sectorBreakdown.SectorName
 
-		sectorCode, err := getSectorCode(sectorBreakdown.SectorName)
+		sectorCode, industryGroupCode, err := GetSectorCodes(sectorBreakdown.SectorName, sectorTaxonomy)
 		if err != nil {
 			log.Warn(ctx, "get sector code failed", "error", err, "SectorName", sectorBreakdown.SectorName)
 		}
 
 		sectorBreakdownModel.SectorCode = sectorCode
+		sectorBreakdownModel.IndustryGroupCode = industryGroupCode
 	}
 
 	if sectorBreakdown.FundPercent != "" {
-		fundPercent, err := strconv.ParseFloat(sectorBreakdown.FundPercent, 64)
+		fundPercent, err := decimal.NewFromString(sectorBreakdown.FundPercent)
 
 		if err != nil {
 			log.Warn(ctx, "parse SectorWeighting.FundPercent failed", "error", err, "FundPercent", sectorBreakdown.FundPercent)
-			fundPercent = 0
+			fundPercent = decimal.Zero
 		}
 
 		sectorBreakdownModel.FundPercent = fundPercent
 	}
 
 	// We will try to use BenchmarkPercent if FundPercent is not available
-	if sectorBreakdownModel.FundPercent == 0 && sectorBreakdown.BenchmarkPercent != "" {
-		benchmarkPercent, err := strconv.ParseFloat(sectorBreakdown.BenchmarkPercent, 64)
+	if sectorBreakdownModel.FundPercent.IsZero() && sectorBreakdown.BenchmarkPercent != "" {
+		benchmarkPercent, err := decimal.NewFromString(sectorBreakdown.BenchmarkPercent)
 
 		if err != nil {
 			log.Warn(ctx, "parse SectorWeighting.BenchmarkPercent failed", "error", err, "BenchmarkPercent", sectorBreakdown.BenchmarkPercent)
-			benchmarkPercent = 0
+			benchmarkPercent = decimal.Zero
 		}
 
 		sectorBreakdownModel.FundPercent = benchmarkPercent
 	}
 
 	// We are only interested in sector that has FundPercent/BenchmarkPercent
-	if sectorBreakdownModel.FundPercent != 0 {
+	if !sectorBreakdownModel.FundPercent.IsZero() {
 		return sectorBreakdownModel, nil
 	}
 
@@ -295,9 +470,11 @@ func newCountryBreakdownModel(ctx context.Context, log logger.ContextLog, countr
 	if countryBreakdown.CountryName != "" {
 		countryBreakdownModel.CountryName = countryBreakdown.CountryName
 
-		countryCode, err := getCountryCode(countryBreakdown.CountryName)
+		countryCode, confidence, err := getCountryCode(countryBreakdown.CountryName)
 		if err != nil {
 			log.Warn(ctx, "get country code failed", "error", err, "CountryName", countryBreakdown.CountryName)
+		} else if confidence < 1 {
+			log.Info(ctx, "fuzzy matched country name", "CountryName", countryBreakdown.CountryName, "CountryCode", countryCode, "Confidence", confidence)
 		}
 
 		countryBreakdownModel.CountryCode = countryCode
@@ -308,14 +485,14 @@ func newCountryBreakdownModel(ctx context.Context, log logger.ContextLog, countr
 	}
 
 	if countryBreakdown.FundMktPercent != "" {
-		fundMktPercent, err := strconv.ParseFloat(countryBreakdown.FundMktPercent, 64)
+		fundMktPercent, err := decimal.NewFromString(countryBreakdown.FundMktPercent)
 
 		if err != nil {
 			log.Warn(ctx, "parse CountryExposure.FundMktPercent failed", "error", err, "FundMktPercent", countryBreakdown.FundMktPercent)
-			fundMktPercent = 0
+			fundMktPercent = decimal.Zero
 		}
 
-		if fundMktPercent == 0 {
+		if fundMktPercent.IsZero() {
 			// not interested in fund market with 0 percent
 			return nil, nil
 		}
@@ -324,11 +501,11 @@ func newCountryBreakdownModel(ctx context.Context, log logger.ContextLog, countr
 	}
 
 	if countryBreakdown.FundTnaPercent != "" {
-		fundTnaPercent, err := strconv.ParseFloat(countryBreakdown.FundTnaPercent, 64)
+		fundTnaPercent, err := decimal.NewFromString(countryBreakdown.FundTnaPercent)
 
 		if err != nil {
 			log.Warn(ctx, "parse CountryExposure.FundTnaPercent failed", "error", err, "FundTnaPercent", countryBreakdown.FundTnaPercent)
-			fundTnaPercent = 0
+			fundTnaPercent = decimal.Zero
 		}
 
 		countryBreakdownModel.FundTnaPercent = fundTnaPercent
@@ -344,13 +521,13 @@ func newDividendHistoryModel(ctx context.Context, log logger.ContextLog, dividen
 	dividentHistoryModel.CurrencyCode = dividendHistories.CurrencyCode
 
 	if dividendHistories.Amount != "" {
-		amount, err := strconv.ParseFloat(dividendHistories.Amount, 64)
+		amount, err := decimal.NewFromString(dividendHistories.Amount)
 		if err != nil {
 			log.Warn(ctx, "parse DistHistory.Amount failed", "error", err, "Amount", dividendHistories.Amount)
-			amount = 0
+			amount = decimal.Zero
 		}
 
-		if amount == 0 {
+		if amount.IsZero() {
 			// not interested in dividend with 0 amount
 			return nil, nil
 		}
@@ -359,7 +536,7 @@ func newDividendHistoryModel(ctx context.Context, log logger.ContextLog, dividen
 	}
 
 	if dividendHistories.CurrencyCode != "" {
-		asOfDate, err := datetime.GetStarDateFromString(dividendHistories.AsOfDate)
+		asOfDate, err := datetime.ParseDate(dividendHistories.AsOfDate)
 		if err != nil {
 			log.Warn(ctx, "parse DistHistory.AsOfDate failed", "error", err, "AsOfDate", dividendHistories.AsOfDate)
 			asOfDate = nil
@@ -371,22 +548,21 @@ func newDividendHistoryModel(ctx context.Context, log logger.ContextLog, dividen
 	return dividentHistoryModel, nil
 }
 
-// getCountryCode gets country code of a given name
-func getCountryCode(name string) (string, error) {
-	for _, country := range consts.Countries {
-		if strings.EqualFold(strings.ToUpper(country.Name), strings.ToUpper(name)) {
-			return country.Alpha3Code, nil
-		}
+// getCountryCode gets country code of a given name and the confidence of the match
+func getCountryCode(name string) (string, float64, error) {
+	if country, confidence := lookup.ResolveCountry(name); country != nil {
+		return country.Alpha3Code, confidence, nil
 	}
-	return "OTH", fmt.Errorf("cannot find country code for country %s", name)
+	return "OTH", 0, fmt.Errorf("cannot find country code for country %s", name)
 }
 
-// getSectorCode gets sector code of a given name
-func getSectorCode(name string) (string, error) {
-	for _, sector := range consts.Sectors {
-		if strings.EqualFold(strings.ToUpper(sector.Name), strings.ToUpper(name)) {
-			return sector.Code, nil
+// GetSectorCodes gets sector code and industry group code, if the name is an industry group, of a given name under a taxonomy version
+func GetSectorCodes(name string, sectorTaxonomy string) (string, string, error) {
+	if classification := taxonomy.Classify(name, sectorTaxonomy); classification != nil {
+		if classification.IndustryGroup != nil {
+			return classification.Sector.Code, classification.IndustryGroup.Code, nil
 		}
+		return classification.Sector.Code, "", nil
 	}
-	return "OTH", fmt.Errorf("cannot find sector code for sector %s", name)
+	return "OTH", "", fmt.Errorf("cannot find sector code for sector %s", name)
 }
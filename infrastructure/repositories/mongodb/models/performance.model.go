@@ -0,0 +1,164 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FundPerformanceModel struct
+type FundPerformanceModel struct {
+	ID                 *primitive.ObjectID   `bson:"_id,omitempty"`
+	CreatedAt          int64                 `bson:"createdAt,omitempty"`
+	ModifiedAt         int64                 `bson:"modifiedAt,omitempty"`
+	Enabled            bool                  `bson:"enabled"`
+	Deleted            bool                  `bson:"deleted"`
+	DelistedAt         int64                 `bson:"delistedAt,omitempty"`
+	Schema             string                `bson:"schema,omitempty"`
+	PortID             string                `bson:"portId,omitempty"`
+	Ticker             string                `bson:"ticker,omitempty"`
+	AsOfDate           *time.Time            `bson:"asOfDate,omitempty"`
+	InceptionDate      *time.Time            `bson:"inceptionDate,omitempty"`
+	BenchmarkName      string                `bson:"benchmarkName,omitempty"`
+	NavReturns         *TrailingReturnsModel `bson:"navReturns,omitempty"`
+	MarketPriceReturns *TrailingReturnsModel `bson:"marketPriceReturns,omitempty"`
+	BenchmarkReturns   *TrailingReturnsModel `bson:"benchmarkReturns,omitempty"`
+}
+
+// TrailingReturnsModel struct, a return is nil if it is not published, e.g. the 10 year return of a younger fund
+type TrailingReturnsModel struct {
+	OneMonth       *decimal.Decimal `bson:"oneMonth,omitempty"`
+	ThreeMonth     *decimal.Decimal `bson:"threeMonth,omitempty"`
+	YearToDate     *decimal.Decimal `bson:"yearToDate,omitempty"`
+	OneYear        *decimal.Decimal `bson:"oneYear,omitempty"`
+	ThreeYear      *decimal.Decimal `bson:"threeYear,omitempty"`
+	FiveYear       *decimal.Decimal `bson:"fiveYear,omitempty"`
+	TenYear        *decimal.Decimal `bson:"tenYear,omitempty"`
+	SinceInception *decimal.Decimal `bson:"sinceInception,omitempty"`
+}
+
+// NewFundPerformanceModel create a fund performance model
+func NewFundPerformanceModel(ctx context.Context, log logger.ContextLog, fundPerformance *entities.FundPerformance, schemaVersion string) (*FundPerformanceModel, error) {
+	var fundPerformanceModel = &FundPerformanceModel{
+		ModifiedAt: time.Now().UTC().Unix(),
+		Enabled:    true,
+		Deleted:    false,
+		Schema:     schemaVersion,
+	}
+
+	if fundPerformance.PortID != "" {
+		fundPerformanceModel.PortID = fundPerformance.PortID
+	}
+
+	if fundPerformance.Ticker != "" {
+		fundPerformanceModel.Ticker = ticker.GenYahooTickerFromVanguardTicker(fundPerformance.Ticker)
+	}
+
+	asOfDate, err := datetime.ParseDate(fundPerformance.AsOfDate)
+	if err != nil {
+		log.Warn(ctx, "parse FundPerformance.AsOfDate failed", "error", err, "AsOfDate", fundPerformance.AsOfDate)
+	}
+	fundPerformanceModel.AsOfDate = asOfDate
+
+	inceptionDate, err := datetime.ParseDate(fundPerformance.InceptionDate)
+	if err != nil {
+		log.Warn(ctx, "parse FundPerformance.InceptionDate failed", "error", err, "InceptionDate", fundPerformance.InceptionDate)
+	}
+	fundPerformanceModel.InceptionDate = inceptionDate
+
+	if fundPerformance.BenchmarkName != "" {
+		fundPerformanceModel.BenchmarkName = strings.TrimSpace(fundPerformance.BenchmarkName)
+	}
+
+	fundPerformanceModel.NavReturns = newTrailingReturnsModel(ctx, log, "NavReturns", fundPerformance.NavReturns)
+	fundPerformanceModel.MarketPriceReturns = newTrailingReturnsModel(ctx, log, "MarketPriceReturns", fundPerformance.MarketPriceReturns)
+	fundPerformanceModel.BenchmarkReturns = newTrailingReturnsModel(ctx, log, "BenchmarkReturns", fundPerformance.BenchmarkReturns)
+
+	return fundPerformanceModel, nil
+}
+
+// ToFundPerformanceRecord converts the model to an entity
+func (m *FundPerformanceModel) ToFundPerformanceRecord() *entities.FundPerformanceRecord {
+	return &entities.FundPerformanceRecord{
+		ModifiedAt:         m.ModifiedAt,
+		PortID:             m.PortID,
+		Ticker:             m.Ticker,
+		AsOfDate:           m.AsOfDate,
+		InceptionDate:      m.InceptionDate,
+		BenchmarkName:      m.BenchmarkName,
+		NavReturns:         m.NavReturns.ToReturnSeries(),
+		MarketPriceReturns: m.MarketPriceReturns.ToReturnSeries(),
+		BenchmarkReturns:   m.BenchmarkReturns.ToReturnSeries(),
+	}
+}
+
+// ToReturnSeries converts the model to an entity
+func (m *TrailingReturnsModel) ToReturnSeries() *entities.ReturnSeries {
+	if m == nil {
+		return nil
+	}
+
+	return &entities.ReturnSeries{
+		OneMonth:       m.OneMonth,
+		ThreeMonth:     m.ThreeMonth,
+		YearToDate:     m.YearToDate,
+		OneYear:        m.OneYear,
+		ThreeYear:      m.ThreeYear,
+		FiveYear:       m.FiveYear,
+		TenYear:        m.TenYear,
+		SinceInception: m.SinceInception,
+	}
+}
+
+// newTrailingReturnsModel create trailing returns model, it is nil if no return is published
+func newTrailingReturnsModel(ctx context.Context, log logger.ContextLog, name string, trailingReturns *entities.TrailingReturns) *TrailingReturnsModel {
+	if trailingReturns == nil {
+		return nil
+	}
+
+	var trailingReturnsModel = &TrailingReturnsModel{}
+
+	fields := []struct {
+		name  string
+		value string
+		dest  **decimal.Decimal
+	}{
+		{"OneMonth", trailingReturns.OneMonth, &trailingReturnsModel.OneMonth},
+		{"ThreeMonth", trailingReturns.ThreeMonth, &trailingReturnsModel.ThreeMonth},
+		{"YearToDate", trailingReturns.YearToDate, &trailingReturnsModel.YearToDate},
+		{"OneYear", trailingReturns.OneYear, &trailingReturnsModel.OneYear},
+		{"ThreeYear", trailingReturns.ThreeYear, &trailingReturnsModel.ThreeYear},
+		{"FiveYear", trailingReturns.FiveYear, &trailingReturnsModel.FiveYear},
+		{"TenYear", trailingReturns.TenYear, &trailingReturnsModel.TenYear},
+		{"SinceInception", trailingReturns.SinceInception, &trailingReturnsModel.SinceInception},
+	}
+
+	published := false
+	for _, field := range fields {
+		if entities.IsUnpublishedReturn(field.value) {
+			continue
+		}
+
+		value, err := decimal.NewFromString(strings.TrimSpace(field.value))
+		if err != nil {
+			log.Warn(ctx, "parse "+name+"."+field.name+" failed", "error", err, field.name, field.value)
+			continue
+		}
+
+		*field.dest = &value
+		published = true
+	}
+
+	if !published {
+		return nil
+	}
+
+	return trailingReturnsModel
+}
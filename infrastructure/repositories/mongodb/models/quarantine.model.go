@@ -0,0 +1,173 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QuarantineModel struct is a scraped document held back by validation.
+// The raw entity is kept as scraped, with its JSON field names, so it can be promoted as is. Amounts are stored as
+// Decimal128 so they keep their precision.
+type QuarantineModel struct {
+	ID         *primitive.ObjectID     `bson:"_id,omitempty"`
+	CreatedAt  int64                   `bson:"createdAt,omitempty"`
+	ModifiedAt int64                   `bson:"modifiedAt,omitempty"`
+	RunID      string                  `bson:"runId,omitempty"`
+	Kind       string                  `bson:"kind,omitempty"`
+	Ticker     string                  `bson:"ticker,omitempty"`
+	PortID     string                  `bson:"portId,omitempty"`
+	Status     string                  `bson:"status,omitempty"`
+	Issues     []*ValidationIssueModel `bson:"issues,omitempty"`
+	Entity     bson.Raw                `bson:"entity,omitempty"`
+}
+
+// ValidationIssueModel struct
+type ValidationIssueModel struct {
+	Rule     string `bson:"rule,omitempty"`
+	Severity string `bson:"severity,omitempty"`
+	Field    string `bson:"field,omitempty"`
+	Value    string `bson:"value,omitempty"`
+	Message  string `bson:"message,omitempty"`
+}
+
+// entityRegistry encodes raw entities, which have no bson tags, under their JSON field names
+var entityRegistry = newEntityRegistry()
+
+// NewQuarantineModel create a quarantine model from the raw entity matching the kind of the item
+func NewQuarantineModel(item *entities.QuarantinedItem) (*QuarantineModel, error) {
+	var entity interface{}
+	switch item.Kind {
+	case entities.VALIDATION_KIND_FUND:
+		entity = item.Fund
+	case entities.VALIDATION_KIND_OVERVIEW:
+		entity = item.Overview
+	case entities.VALIDATION_KIND_HOLDING:
+		entity = item.Holding
+	case entities.VALIDATION_KIND_DISTRIBUTION:
+		entity = item.Distribution
+	case entities.VALIDATION_KIND_PERFORMANCE:
+		entity = item.Performance
+	default:
+		return nil, fmt.Errorf("unsupported quarantine kind %q", item.Kind)
+	}
+
+	doc, err := bson.MarshalWithRegistry(entityRegistry, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*ValidationIssueModel
+	for _, issue := range item.Issues {
+		issues = append(issues, &ValidationIssueModel{
+			Rule:     issue.Rule,
+			Severity: issue.Severity,
+			Field:    issue.Field,
+			Value:    issue.Value,
+			Message:  issue.Message,
+		})
+	}
+
+	now := time.Now().UTC().Unix()
+
+	return &QuarantineModel{
+		CreatedAt:  now,
+		ModifiedAt: now,
+		RunID:      item.RunID,
+		Kind:       item.Kind,
+		Ticker:     item.Ticker,
+		PortID:     item.PortID,
+		Status:     entities.QUARANTINE_STATUS_PENDING,
+		Issues:     issues,
+		Entity:     doc,
+	}, nil
+}
+
+// ToQuarantinedItem converts the model back to a quarantined item, the raw entity is decoded only if requested
+func (m *QuarantineModel) ToQuarantinedItem(withEntity bool) (*entities.QuarantinedItem, error) {
+	quarantinedAt := time.Unix(m.CreatedAt, 0).UTC()
+
+	item := &entities.QuarantinedItem{
+		RunID:         m.RunID,
+		Kind:          m.Kind,
+		Ticker:        m.Ticker,
+		PortID:        m.PortID,
+		Status:        m.Status,
+		QuarantinedAt: &quarantinedAt,
+	}
+
+	if m.ID != nil {
+		item.ID = m.ID.Hex()
+	}
+
+	if m.Status != entities.QUARANTINE_STATUS_PENDING {
+		resolvedAt := time.Unix(m.ModifiedAt, 0).UTC()
+		item.ResolvedAt = &resolvedAt
+	}
+
+	for _, issue := range m.Issues {
+		item.Issues = append(item.Issues, &entities.ValidationIssue{
+			Rule:     issue.Rule,
+			Severity: issue.Severity,
+			Field:    issue.Field,
+			Value:    issue.Value,
+			Message:  issue.Message,
+		})
+	}
+
+	if !withEntity {
+		return item, nil
+	}
+
+	var err error
+	switch m.Kind {
+	case entities.VALIDATION_KIND_FUND:
+		err = bson.UnmarshalWithRegistry(entityRegistry, m.Entity, &item.Fund)
+	case entities.VALIDATION_KIND_OVERVIEW:
+		err = bson.UnmarshalWithRegistry(entityRegistry, m.Entity, &item.Overview)
+	case entities.VALIDATION_KIND_HOLDING:
+		err = bson.UnmarshalWithRegistry(entityRegistry, m.Entity, &item.Holding)
+	case entities.VALIDATION_KIND_DISTRIBUTION:
+		err = bson.UnmarshalWithRegistry(entityRegistry, m.Entity, &item.Distribution)
+	case entities.VALIDATION_KIND_PERFORMANCE:
+		err = bson.UnmarshalWithRegistry(entityRegistry, m.Entity, &item.Performance)
+	default:
+		err = fmt.Errorf("unsupported quarantine kind %q", m.Kind)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// newEntityRegistry creates a registry whose struct codec names a field by its bson tag, or by its JSON tag if it has none
+func newEntityRegistry() *bsoncodec.Registry {
+	tagParser := bsoncodec.StructTagParserFunc(func(sf reflect.StructField) (bsoncodec.StructTags, error) {
+		if _, ok := sf.Tag.Lookup("bson"); ok {
+			return bsoncodec.DefaultStructTagParser(sf)
+		}
+
+		if tag, ok := sf.Tag.Lookup("json"); ok {
+			sf.Tag = reflect.StructTag(fmt.Sprintf("bson:%q", tag))
+		}
+
+		return bsoncodec.DefaultStructTagParser(sf)
+	})
+
+	structCodec, err := bsoncodec.NewStructCodec(tagParser)
+	if err != nil {
+		panic(err)
+	}
+
+	return bson.NewRegistryBuilder().
+		RegisterDefaultEncoder(reflect.Struct, structCodec).
+		RegisterDefaultDecoder(reflect.Struct, structCodec).
+		Build()
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestQuarantineModelKeepsPrecision(t *testing.T) {
+	// more digits than a float64 holds
+	amount := dec("1234567.0123456789012345")
+
+	distribution := &entities.FundDistribution{}
+	distribution.DistributionDetails.Ticker = "VAB"
+	distribution.DistributionDetails.DistributionHistories = []*entities.DistributionHistory{
+		{Type: "Income", DistributionAmount: amount, ExDividendDate: "2021-06-01"},
+	}
+
+	model, err := NewQuarantineModel(&entities.QuarantinedItem{Kind: entities.VALIDATION_KIND_DISTRIBUTION, Distribution: distribution})
+	if err != nil {
+		t.Fatalf("NewQuarantineModel error = %v", err)
+	}
+
+	raw, err := bson.Marshal(model)
+	if err != nil {
+		t.Fatalf("marshal quarantine model failed: %v", err)
+	}
+
+	var stored QuarantineModel
+	if err := bson.Unmarshal(raw, &stored); err != nil {
+		t.Fatalf("unmarshal quarantine model failed: %v", err)
+	}
+
+	item, err := stored.ToQuarantinedItem(true)
+	if err != nil {
+		t.Fatalf("ToQuarantinedItem error = %v", err)
+	}
+
+	if got := item.Distribution.DistributionDetails.DistributionHistories[0].DistributionAmount; !got.Equal(amount) {
+		t.Errorf("distribution amount = %v, want %v", got, amount)
+	}
+
+	// the entity is stored under its JSON field names
+	if ticker, _ := stored.Entity.Lookup("distributions", "ticker").StringValueOK(); ticker != "VAB" {
+		t.Errorf("stored distributions.ticker = %q, want VAB", ticker)
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/identifier"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// SymbologyModel struct holds the raw exchange ticker and the identifiers derived from it
+type SymbologyModel struct {
+	Exchange  string `bson:"exchange,omitempty"`
+	Yahoo     string `bson:"yahoo,omitempty"`
+	Google    string `bson:"google,omitempty"`
+	Bloomberg string `bson:"bloomberg,omitempty"`
+	Refinitiv string `bson:"refinitiv,omitempty"`
+	Isin      string `bson:"isin,omitempty"`
+	Sedol     string `bson:"sedol,omitempty"`
+	Cusip     string `bson:"cusip,omitempty"`
+}
+
+// SymbologyFields are the stored fields a fund can be looked up by
+var SymbologyFields = []string{
+	"symbology.exchange",
+	"symbology.yahoo",
+	"symbology.google",
+	"symbology.bloomberg",
+	"symbology.refinitiv",
+	"symbology.isin",
+	"symbology.sedol",
+	"symbology.cusip",
+}
+
+// NewSymbologyModel create a symbology model from an exchange ticker, the ISIN and the SEDOL are optional and
+// kept only if their check digit is valid
+func NewSymbologyModel(exchangeTicker, isin, sedol string) *SymbologyModel {
+	exchangeTicker = ticker.ParseExchangeTicker(exchangeTicker)
+	if exchangeTicker == "" {
+		return nil
+	}
+
+	symbologyModel := &SymbologyModel{
+		Exchange:  exchangeTicker,
+		Yahoo:     ticker.GenYahooTickerFromVanguardTicker(exchangeTicker),
+		Google:    ticker.GenGoogleTicker(exchangeTicker),
+		Bloomberg: ticker.GenBloombergTicker(exchangeTicker),
+		Refinitiv: ticker.GenRefinitivTicker(exchangeTicker),
+	}
+
+	if identifier.IsValidIsin(isin) {
+		symbologyModel.Isin = identifier.Normalize(isin)
+		symbologyModel.Cusip = identifier.GetCusipFromIsin(isin)
+	}
+
+	if identifier.IsValidSedol(sedol) {
+		symbologyModel.Sedol = identifier.Normalize(sedol)
+	}
+
+	return symbologyModel
+}
+
+// ToSymbology converts the model to an entity
+func (m *SymbologyModel) ToSymbology() *entities.Symbology {
+	if m == nil {
+		return nil
+	}
+
+	return &entities.Symbology{
+		Exchange:  m.Exchange,
+		Yahoo:     m.Yahoo,
+		Google:    m.Google,
+		Bloomberg: m.Bloomberg,
+		Refinitiv: m.Refinitiv,
+		Isin:      m.Isin,
+		Sedol:     m.Sedol,
+		Cusip:     m.Cusip,
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -17,29 +19,36 @@ import (
 
 // FundMongo struct
 type FundMongo struct {
-	db     *mongo.Database
-	client *mongo.Client
-	log    logger.ContextLog
-	conf   *config.MongoConfig
+	db       *mongo.Database
+	client   *mongo.Client
+	log      logger.ContextLog
+	conf     *config.MongoConfig
+	timeouts *timeouts
 }
 
 // NewFundMongo creates new fund mongo repo
 func NewFundMongo(db *mongo.Database, log logger.ContextLog, conf *config.MongoConfig) (*FundMongo, error) {
+	// validate operation timeouts from the config
+	t, err := newTimeouts(conf)
+	if err != nil {
+		return nil, err
+	}
+
 	if db != nil {
 		return &FundMongo{
-			db:   db,
-			log:  log,
-			conf: conf,
+			db:       db,
+			log:      log,
+			conf:     conf,
+			timeouts: t,
 		}, nil
 	}
 
-	// set context with timeout from the config
-	// create new context for the query
-	ctx, cancel := createContext(context.Background(), conf.TimeoutMS)
+	// create new context for the connection
+	ctx, cancel := createContext(context.Background(), t.connect)
 	defer cancel()
 
 	// set mongo client options
-	clientOptions := options.Client()
+	clientOptions := options.Client().SetConnectTimeout(t.connect)
 
 	// set min pool size
 	if conf.MinPoolSize > 0 {
@@ -66,16 +75,19 @@ func NewFundMongo(db *mongo.Database, log logger.ContextLog, conf *config.MongoC
 	}
 
 	return &FundMongo{
-		db:     client.Database(conf.Dbname),
-		client: client,
-		log:    log,
-		conf:   conf,
+		db:       client.Database(conf.Dbname),
+		client:   client,
+		log:      log,
+		conf:     conf,
+		timeouts: t,
 	}, nil
 }
 
 // Close disconnect from database
 func (r *FundMongo) Close() {
-	ctx := context.Background()
+	ctx, cancel := createContext(context.Background(), r.timeouts.connect)
+	defer cancel()
+
 	r.log.Info(ctx, "close mongo client")
 
 	if r.client == nil {
@@ -94,7 +106,7 @@ func (r *FundMongo) Close() {
 // InsertFund inserts new fund
 func (r *FundMongo) InsertFund(ctx context.Context, fund *entities.Fund) error {
 	// create new context for the query
-	ctx, cancel := createContext(ctx, r.conf.TimeoutMS)
+	ctx, cancel := createContext(ctx, r.timeouts.write)
 	defer cancel()
 
 	fundModel, err := models.NewFundModel(ctx, r.log, fund, r.conf.SchemaVersion)
@@ -116,6 +128,13 @@ func (r *FundMongo) InsertFund(ctx context.Context, fund *entities.Fund) error {
 		Value: fundModel.Ticker,
 	}}
 
+	// compare with the stored fund before the upsert
+	var storedFundModel *models.FundModel
+	if err := r.findOne(ctx, col, filter, &storedFundModel); err != nil {
+		return err
+	}
+	r.insertFundChanges(ctx, models.DiffFundModels(storedFundModel, fundModel, r.conf.SchemaVersion))
+
 	update := bson.D{
 		{
 			Key:   "$set",
@@ -128,6 +147,14 @@ func (r *FundMongo) InsertFund(ctx context.Context, fund *entities.Fund) error {
 				Value: time.Now().UTC().Unix(),
 			}},
 		},
+		{
+			// a delisted fund which reappears is live again
+			Key: "$unset",
+			Value: bson.D{{
+				Key:   "delistedAt",
+				Value: "",
+			}},
+		},
 	}
 
 	opts := options.Update().SetUpsert(true)
@@ -144,7 +171,7 @@ func (r *FundMongo) InsertFund(ctx context.Context, fund *entities.Fund) error {
 // InsertFundOverview inserts fund overview
 func (r *FundMongo) InsertFundOverview(ctx context.Context, fundOverview *entities.FundOverview) error {
 	// create new context for the query
-	ctx, cancel := createContext(ctx, r.conf.TimeoutMS)
+	ctx, cancel := createContext(ctx, r.timeouts.write)
 	defer cancel()
 
 	fundOverviewModel, err := models.NewOverviewModel(ctx, r.log, fundOverview, r.conf.SchemaVersion)
@@ -165,6 +192,13 @@ func (r *FundMongo) InsertFundOverview(ctx context.Context, fundOverview *entiti
 		Value: fundOverviewModel.Ticker,
 	}}
 
+	// compare with the stored fund overview before the upsert
+	var storedFundOverviewModel *models.FundOverviewModel
+	if err := r.findOne(ctx, col, filter, &storedFundOverviewModel); err != nil {
+		return err
+	}
+	r.insertFundChanges(ctx, models.DiffFundOverviewModels(storedFundOverviewModel, fundOverviewModel, decimal.NewFromFloat(consts.WEIGHT_SHIFT_THRESHOLD), r.conf.SchemaVersion))
+
 	update := bson.D{
 		{
 			Key:   "$set",
@@ -177,6 +211,14 @@ func (r *FundMongo) InsertFundOverview(ctx context.Context, fundOverview *entiti
 				Value: time.Now().UTC().Unix(),
 			}},
 		},
+		{
+			// a delisted fund which reappears is live again
+			Key: "$unset",
+			Value: bson.D{{
+				Key:   "delistedAt",
+				Value: "",
+			}},
+		},
 	}
 
 	opts := options.Update().SetUpsert(true)
@@ -193,7 +235,7 @@ func (r *FundMongo) InsertFundOverview(ctx context.Context, fundOverview *entiti
 // InsertFundHolding inserts fund holding
 func (r *FundMongo) InsertFundHolding(ctx context.Context, fundHolding *entities.FundHolding) error {
 	// create new context for the query
-	ctx, cancel := createContext(ctx, r.conf.TimeoutMS)
+	ctx, cancel := createContext(ctx, r.timeouts.write)
 	defer cancel()
 
 	fundHoldingModel, err := models.NewFundHoldingModel(ctx, r.log, fundHolding, r.conf.SchemaVersion)
@@ -226,6 +268,14 @@ func (r *FundMongo) InsertFundHolding(ctx context.Context, fundHolding *entities
 				Value: time.Now().UTC().Unix(),
 			}},
 		},
+		{
+			// a delisted fund which reappears is live again
+			Key: "$unset",
+			Value: bson.D{{
+				Key:   "delistedAt",
+				Value: "",
+			}},
+		},
 	}
 
 	opts := options.Update().SetUpsert(true)
@@ -242,7 +292,7 @@ func (r *FundMongo) InsertFundHolding(ctx context.Context, fundHolding *entities
 // InsertFundDistribution inserts fund distribution
 func (r *FundMongo) InsertFundDistribution(ctx context.Context, fundDistribution *entities.FundDistribution) error {
 	// create new context for the query
-	ctx, cancel := createContext(ctx, r.conf.TimeoutMS)
+	ctx, cancel := createContext(ctx, r.timeouts.write)
 	defer cancel()
 
 	fundDistributionModel, err := models.NewFundDistributionModel(ctx, r.log, fundDistribution, r.conf.SchemaVersion)
@@ -263,6 +313,13 @@ func (r *FundMongo) InsertFundDistribution(ctx context.Context, fundDistribution
 		Value: fundDistributionModel.PortID,
 	}}
 
+	// compare with the stored fund distribution before the upsert
+	var storedFundDistributionModel *models.FundDistributionModel
+	if err := r.findOne(ctx, col, filter, &storedFundDistributionModel); err != nil {
+		return err
+	}
+	r.insertFundChanges(ctx, models.DiffFundDistributionModels(storedFundDistributionModel, fundDistributionModel, r.conf.SchemaVersion))
+
 	update := bson.D{
 		{
 			Key:   "$set",
@@ -275,6 +332,72 @@ func (r *FundMongo) InsertFundDistribution(ctx context.Context, fundDistribution
 				Value: time.Now().UTC().Unix(),
 			}},
 		},
+		{
+			// a delisted fund which reappears is live again
+			Key: "$unset",
+			Value: bson.D{{
+				Key:   "delistedAt",
+				Value: "",
+			}},
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+
+	_, err = col.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		r.log.Error(ctx, "update one failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// InsertFundPerformance inserts fund performance
+func (r *FundMongo) InsertFundPerformance(ctx context.Context, fundPerformance *entities.FundPerformance) error {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.write)
+	defer cancel()
+
+	fundPerformanceModel, err := models.NewFundPerformanceModel(ctx, r.log, fundPerformance, r.conf.SchemaVersion)
+	if err != nil {
+		r.log.Error(ctx, "create model failed", "error", err)
+		return err
+	}
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_PERFORMANCE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{{
+		Key:   "portId",
+		Value: fundPerformanceModel.PortID,
+	}}
+
+	update := bson.D{
+		{
+			Key:   "$set",
+			Value: fundPerformanceModel,
+		},
+		{
+			Key: "$setOnInsert",
+			Value: bson.D{{
+				Key:   "createdAt",
+				Value: time.Now().UTC().Unix(),
+			}},
+		},
+		{
+			// a delisted fund which reappears is live again
+			Key: "$unset",
+			Value: bson.D{{
+				Key:   "delistedAt",
+				Value: "",
+			}},
+		},
 	}
 
 	opts := options.Update().SetUpsert(true)
@@ -288,12 +411,558 @@ func (r *FundMongo) InsertFundDistribution(ctx context.Context, fundDistribution
 	return nil
 }
 
+// FindFunds finds all live funds
+func (r *FundMongo) FindFunds(ctx context.Context) ([]*entities.FundRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_LIST_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	cur, err := col.Find(ctx, liveFilter())
+	if err != nil {
+		r.log.Error(ctx, "find funds failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundModels []*models.FundModel
+	if err := cur.All(ctx, &fundModels); err != nil {
+		r.log.Error(ctx, "decode funds failed", "error", err)
+		return nil, err
+	}
+
+	var funds []*entities.FundRecord
+	for _, fundModel := range fundModels {
+		funds = append(funds, fundModel.ToFundRecord())
+	}
+
+	return funds, nil
+}
+
+// FindFundOverviews finds all live fund overviews
+func (r *FundMongo) FindFundOverviews(ctx context.Context) ([]*entities.FundOverviewRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_OVERVIEW_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	cur, err := col.Find(ctx, liveFilter())
+	if err != nil {
+		r.log.Error(ctx, "find fund overviews failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundOverviewModels []*models.FundOverviewModel
+	if err := cur.All(ctx, &fundOverviewModels); err != nil {
+		r.log.Error(ctx, "decode fund overviews failed", "error", err)
+		return nil, err
+	}
+
+	var fundOverviews []*entities.FundOverviewRecord
+	for _, fundOverviewModel := range fundOverviewModels {
+		fundOverviews = append(fundOverviews, fundOverviewModel.ToFundOverviewRecord())
+	}
+
+	return fundOverviews, nil
+}
+
+// FindFundHoldings finds all live fund holdings
+func (r *FundMongo) FindFundHoldings(ctx context.Context) ([]*entities.FundHoldingRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_HOLDING_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	cur, err := col.Find(ctx, liveFilter())
+	if err != nil {
+		r.log.Error(ctx, "find fund holdings failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundHoldingModels []*models.FundHoldingModel
+	if err := cur.All(ctx, &fundHoldingModels); err != nil {
+		r.log.Error(ctx, "decode fund holdings failed", "error", err)
+		return nil, err
+	}
+
+	var fundHoldings []*entities.FundHoldingRecord
+	for _, fundHoldingModel := range fundHoldingModels {
+		fundHoldings = append(fundHoldings, fundHoldingModel.ToFundHoldingRecord())
+	}
+
+	return fundHoldings, nil
+}
+
+// FindFundDistributions finds all live fund distributions
+func (r *FundMongo) FindFundDistributions(ctx context.Context) ([]*entities.FundDistributionRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	cur, err := col.Find(ctx, liveFilter())
+	if err != nil {
+		r.log.Error(ctx, "find fund distributions failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundDistributionModels []*models.FundDistributionModel
+	if err := cur.All(ctx, &fundDistributionModels); err != nil {
+		r.log.Error(ctx, "decode fund distributions failed", "error", err)
+		return nil, err
+	}
+
+	var fundDistributions []*entities.FundDistributionRecord
+	for _, fundDistributionModel := range fundDistributionModels {
+		fundDistributions = append(fundDistributions, fundDistributionModel.ToFundDistributionRecord())
+	}
+
+	return fundDistributions, nil
+}
+
+// FindFundOverviewsByTickers finds live fund overviews of given tickers
+func (r *FundMongo) FindFundOverviewsByTickers(ctx context.Context, tickers []string) ([]*entities.FundOverviewRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_OVERVIEW_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := append(liveFilter(), bson.E{
+		Key: "ticker",
+		Value: bson.D{{
+			Key:   "$in",
+			Value: tickers,
+		}},
+	})
+
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		r.log.Error(ctx, "find fund overviews failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundOverviewModels []*models.FundOverviewModel
+	if err := cur.All(ctx, &fundOverviewModels); err != nil {
+		r.log.Error(ctx, "decode fund overviews failed", "error", err)
+		return nil, err
+	}
+
+	var fundOverviews []*entities.FundOverviewRecord
+	for _, fundOverviewModel := range fundOverviewModels {
+		fundOverviews = append(fundOverviews, fundOverviewModel.ToFundOverviewRecord())
+	}
+
+	return fundOverviews, nil
+}
+
+// FindFundHoldingsByTickers finds live fund holdings of given tickers
+func (r *FundMongo) FindFundHoldingsByTickers(ctx context.Context, tickers []string) ([]*entities.FundHoldingRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_HOLDING_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := append(liveFilter(), bson.E{
+		Key: "ticker",
+		Value: bson.D{{
+			Key:   "$in",
+			Value: tickers,
+		}},
+	})
+
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		r.log.Error(ctx, "find fund holdings failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundHoldingModels []*models.FundHoldingModel
+	if err := cur.All(ctx, &fundHoldingModels); err != nil {
+		r.log.Error(ctx, "decode fund holdings failed", "error", err)
+		return nil, err
+	}
+
+	var fundHoldings []*entities.FundHoldingRecord
+	for _, fundHoldingModel := range fundHoldingModels {
+		fundHoldings = append(fundHoldings, fundHoldingModel.ToFundHoldingRecord())
+	}
+
+	return fundHoldings, nil
+}
+
+// FindFundDistributionsByTickers finds live fund distributions of given tickers
+func (r *FundMongo) FindFundDistributionsByTickers(ctx context.Context, tickers []string) ([]*entities.FundDistributionRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := append(liveFilter(), bson.E{
+		Key: "ticker",
+		Value: bson.D{{
+			Key:   "$in",
+			Value: tickers,
+		}},
+	})
+
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		r.log.Error(ctx, "find fund distributions failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundDistributionModels []*models.FundDistributionModel
+	if err := cur.All(ctx, &fundDistributionModels); err != nil {
+		r.log.Error(ctx, "decode fund distributions failed", "error", err)
+		return nil, err
+	}
+
+	var fundDistributions []*entities.FundDistributionRecord
+	for _, fundDistributionModel := range fundDistributionModels {
+		fundDistributions = append(fundDistributions, fundDistributionModel.ToFundDistributionRecord())
+	}
+
+	return fundDistributions, nil
+}
+
+// FindFundPerformances finds all live fund performances
+func (r *FundMongo) FindFundPerformances(ctx context.Context) ([]*entities.FundPerformanceRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_PERFORMANCE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	cur, err := col.Find(ctx, liveFilter())
+	if err != nil {
+		r.log.Error(ctx, "find fund performances failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundPerformanceModels []*models.FundPerformanceModel
+	if err := cur.All(ctx, &fundPerformanceModels); err != nil {
+		r.log.Error(ctx, "decode fund performances failed", "error", err)
+		return nil, err
+	}
+
+	var fundPerformances []*entities.FundPerformanceRecord
+	for _, fundPerformanceModel := range fundPerformanceModels {
+		fundPerformances = append(fundPerformances, fundPerformanceModel.ToFundPerformanceRecord())
+	}
+
+	return fundPerformances, nil
+}
+
+// FindFundPerformancesByTickers finds live fund performances of given tickers
+func (r *FundMongo) FindFundPerformancesByTickers(ctx context.Context, tickers []string) ([]*entities.FundPerformanceRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_PERFORMANCE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := append(liveFilter(), bson.E{
+		Key: "ticker",
+		Value: bson.D{{
+			Key:   "$in",
+			Value: tickers,
+		}},
+	})
+
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		r.log.Error(ctx, "find fund performances failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundPerformanceModels []*models.FundPerformanceModel
+	if err := cur.All(ctx, &fundPerformanceModels); err != nil {
+		r.log.Error(ctx, "decode fund performances failed", "error", err)
+		return nil, err
+	}
+
+	var fundPerformances []*entities.FundPerformanceRecord
+	for _, fundPerformanceModel := range fundPerformanceModels {
+		fundPerformances = append(fundPerformances, fundPerformanceModel.ToFundPerformanceRecord())
+	}
+
+	return fundPerformances, nil
+}
+
+// ReconcileFundList soft deletes funds, overviews, holdings, distributions and performances which are no longer in the scraped fund list
+func (r *FundMongo) ReconcileFundList(ctx context.Context, tickers []string) error {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.bulk)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_LIST_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	var yahooTickers []string
+	for _, t := range tickers {
+		yahooTickers = append(yahooTickers, ticker.GenYahooTickerFromVanguardTicker(t))
+	}
+
+	// live documents whose ticker is not in the fund list, a document without a ticker is not a vanished fund
+	filter := bson.D{
+		{
+			Key: "ticker",
+			Value: bson.D{
+				{
+					Key:   "$nin",
+					Value: yahooTickers,
+				},
+				{
+					Key:   "$exists",
+					Value: true,
+				},
+				{
+					Key:   "$ne",
+					Value: "",
+				},
+			},
+		},
+		{
+			Key: "deleted",
+			Value: bson.D{{
+				Key:   "$ne",
+				Value: true,
+			}},
+		},
+	}
+
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		r.log.Error(ctx, "find vanished funds failed", "error", err)
+		return err
+	}
+	defer cur.Close(ctx)
+
+	var fundModels []*models.FundModel
+	if err := cur.All(ctx, &fundModels); err != nil {
+		r.log.Error(ctx, "decode vanished funds failed", "error", err)
+		return err
+	}
+
+	if len(fundModels) == 0 {
+		return nil
+	}
+
+	var changes []*models.FundChangeModel
+	for _, fundModel := range fundModels {
+		changes = append(changes, models.NewFundChangeModel(fundModel.Ticker, fundModel.PortID, entities.CHANGE_TYPE_FUND_VANISHED, "ticker", fundModel.Name, nil, r.conf.SchemaVersion))
+	}
+	r.insertFundChanges(ctx, changes)
+
+	now := time.Now().UTC().Unix()
+	update := bson.D{{
+		Key: "$set",
+		Value: bson.D{
+			{Key: "enabled", Value: false},
+			{Key: "deleted", Value: true},
+			{Key: "delistedAt", Value: now},
+			{Key: "modifiedAt", Value: now},
+		},
+	}}
+
+	for _, key := range []string{
+		consts.VANGUARD_FUND_LIST_COLLECTION,
+		consts.VANGUARD_FUND_OVERVIEW_COLLECTION,
+		consts.VANGUARD_FUND_HOLDING_COLLECTION,
+		consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION,
+		consts.VANGUARD_FUND_PERFORMANCE_COLLECTION,
+	} {
+		colname, ok := r.conf.Colnames[key]
+		if !ok {
+			r.log.Error(ctx, "cannot find collection name", "collection", key)
+			return fmt.Errorf("cannot find collection name")
+		}
+
+		result, err := r.db.Collection(colname).UpdateMany(ctx, filter, update)
+		if err != nil {
+			r.log.Error(ctx, "soft delete delisted funds failed", "collection", colname, "error", err)
+			return err
+		}
+
+		r.log.Info(ctx, "soft delete delisted funds", "collection", colname, "modified", result.ModifiedCount)
+	}
+
+	return nil
+}
+
+// FindFundChanges finds fund changes detected since a unix time
+func (r *FundMongo) FindFundChanges(ctx context.Context, since int64) ([]*entities.FundChange, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_CHANGE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{{
+		Key: "createdAt",
+		Value: bson.D{{
+			Key:   "$gte",
+			Value: since,
+		}},
+	}}
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: "createdAt", Value: 1},
+		{Key: "ticker", Value: 1},
+	})
+
+	cur, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		r.log.Error(ctx, "find fund changes failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var fundChangeModels []*models.FundChangeModel
+	if err := cur.All(ctx, &fundChangeModels); err != nil {
+		r.log.Error(ctx, "decode fund changes failed", "error", err)
+		return nil, err
+	}
+
+	var fundChanges []*entities.FundChange
+	for _, fundChangeModel := range fundChangeModels {
+		fundChanges = append(fundChanges, fundChangeModel.ToFundChange())
+	}
+
+	return fundChanges, nil
+}
+
 ///////////////////////////////////////////////////////////
 // Implement helper function
 ///////////////////////////////////////////////////////////
 
-// createContext create a new context with timeout
-func createContext(ctx context.Context, t uint64) (context.Context, context.CancelFunc) {
-	timeout := time.Duration(t) * time.Millisecond
-	return context.WithTimeout(ctx, timeout*time.Millisecond)
+// liveFilter matches documents which are not soft deleted
+func liveFilter() bson.D {
+	return bson.D{{
+		Key: "deleted",
+		Value: bson.D{{
+			Key:   "$ne",
+			Value: true,
+		}},
+	}}
+}
+
+// findOne decodes the document matching the filter into out, out is left untouched if there is no such document
+func (r *FundMongo) findOne(ctx context.Context, col *mongo.Collection, filter interface{}, out interface{}) error {
+	err := col.FindOne(ctx, filter).Decode(out)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+
+	if err != nil {
+		r.log.Error(ctx, "find one failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// insertFundChanges persists detected changes. A failure is logged only, it must not fail the scrape.
+func (r *FundMongo) insertFundChanges(ctx context.Context, changes []*models.FundChangeModel) {
+	if len(changes) == 0 {
+		return
+	}
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_CHANGE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return
+	}
+	col := r.db.Collection(colname)
+
+	var docs []interface{}
+	for _, change := range changes {
+		r.log.Info(ctx, "fund change detected", "ticker", change.Ticker, "type", change.Type, "field", change.Field, "oldValue", change.OldValue, "newValue", change.NewValue)
+		docs = append(docs, change)
+	}
+
+	if _, err := col.InsertMany(ctx, docs); err != nil {
+		r.log.Error(ctx, "insert fund changes failed", "error", err)
+	}
 }
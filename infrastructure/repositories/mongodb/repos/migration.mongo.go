@@ -0,0 +1,221 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Implement migration interface
+///////////////////////////////////////////////////////////////////////////////
+
+// FindSchemaVersions finds distinct schema versions stamped on the documents of a collection
+func (r *FundMongo) FindSchemaVersions(ctx context.Context, collection string) ([]string, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.bulk)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[collection]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name", "collection", collection)
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	values, err := col.Distinct(ctx, "schema", bson.D{})
+	if err != nil {
+		r.log.Error(ctx, "find schema versions failed", "collection", colname, "error", err)
+		return nil, err
+	}
+
+	var versions []string
+	for _, value := range values {
+		if version, ok := value.(string); ok {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, nil
+}
+
+// FindDocumentsBySchema finds raw documents of a collection stamped with a schema version
+func (r *FundMongo) FindDocumentsBySchema(ctx context.Context, collection string, version string) ([]bson.M, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.bulk)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[collection]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name", "collection", collection)
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{{
+		Key:   "schema",
+		Value: version,
+	}}
+
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		r.log.Error(ctx, "find documents by schema failed", "collection", colname, "schema", version, "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var docs []bson.M
+	if err := cur.All(ctx, &docs); err != nil {
+		r.log.Error(ctx, "decode documents failed", "collection", colname, "error", err)
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// ReplaceDocument replaces a raw document of a collection by its id
+func (r *FundMongo) ReplaceDocument(ctx context.Context, collection string, doc bson.M) error {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.write)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[collection]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name", "collection", collection)
+		return fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{{
+		Key:   "_id",
+		Value: doc["_id"],
+	}}
+
+	if _, err := col.ReplaceOne(ctx, filter, doc); err != nil {
+		r.log.Error(ctx, "replace one failed", "collection", colname, "id", doc["_id"], "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// EnsureUniqueIndex creates a unique index on a field of a collection unless it exists already
+func (r *FundMongo) EnsureUniqueIndex(ctx context.Context, collection string, key string) error {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.bulk)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[collection]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name", "collection", collection)
+		return fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: key, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	if _, err := col.Indexes().CreateOne(ctx, index); err != nil {
+		r.log.Error(ctx, "create unique index failed", "collection", colname, "key", key, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// EnsureIndex creates an index on a field of a collection unless it exists already
+func (r *FundMongo) EnsureIndex(ctx context.Context, collection string, key string) error {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.bulk)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[collection]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name", "collection", collection)
+		return fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: key, Value: 1}},
+	}
+
+	if _, err := col.Indexes().CreateOne(ctx, index); err != nil {
+		r.log.Error(ctx, "create index failed", "collection", colname, "key", key, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// InsertMigration inserts an entry of the migrations-applied ledger
+func (r *FundMongo) InsertMigration(ctx context.Context, migration *entities.MigrationResult) error {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.write)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_MIGRATION_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	if _, err := col.InsertOne(ctx, models.NewMigrationModel(migration)); err != nil {
+		r.log.Error(ctx, "insert one failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindMigrations finds all entries of the migrations-applied ledger, oldest first
+func (r *FundMongo) FindMigrations(ctx context.Context) ([]*entities.MigrationResult, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_MIGRATION_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	cur, err := col.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		r.log.Error(ctx, "find migrations failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var migrationModels []*models.MigrationModel
+	if err := cur.All(ctx, &migrationModels); err != nil {
+		r.log.Error(ctx, "decode migrations failed", "error", err)
+		return nil, err
+	}
+
+	var migrationResults []*entities.MigrationResult
+	for _, migrationModel := range migrationModels {
+		migrationResults = append(migrationResults, migrationModel.ToMigrationResult())
+	}
+
+	return migrationResults, nil
+}
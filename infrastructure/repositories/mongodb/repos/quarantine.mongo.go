@@ -0,0 +1,247 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Implement quarantine interface
+///////////////////////////////////////////////////////////////////////////////
+
+// kindCollections maps the kind of a quarantined item to the live collection it is promoted to
+var kindCollections = map[string]string{
+	entities.VALIDATION_KIND_FUND:         consts.VANGUARD_FUND_LIST_COLLECTION,
+	entities.VALIDATION_KIND_OVERVIEW:     consts.VANGUARD_FUND_OVERVIEW_COLLECTION,
+	entities.VALIDATION_KIND_HOLDING:      consts.VANGUARD_FUND_HOLDING_COLLECTION,
+	entities.VALIDATION_KIND_DISTRIBUTION: consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION,
+	entities.VALIDATION_KIND_PERFORMANCE:  consts.VANGUARD_FUND_PERFORMANCE_COLLECTION,
+}
+
+// InsertQuarantinedItem inserts a scraped document held back by validation
+func (r *FundMongo) InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.write)
+	defer cancel()
+
+	quarantineModel, err := models.NewQuarantineModel(item)
+	if err != nil {
+		r.log.Error(ctx, "create model failed", "error", err)
+		return err
+	}
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_QUARANTINE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	if _, err := col.InsertOne(ctx, quarantineModel); err != nil {
+		r.log.Error(ctx, "insert one failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindQuarantinedItems finds quarantined items with a status without their raw entity, newest first.
+// An empty status matches every item.
+func (r *FundMongo) FindQuarantinedItems(ctx context.Context, status string) ([]*entities.QuarantinedItem, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_QUARANTINE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{}
+	if status != "" {
+		filter = bson.D{{
+			Key:   "status",
+			Value: status,
+		}}
+	}
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: "createdAt", Value: -1},
+		{Key: "ticker", Value: 1},
+	})
+
+	cur, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		r.log.Error(ctx, "find quarantined items failed", "error", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var quarantineModels []*models.QuarantineModel
+	if err := cur.All(ctx, &quarantineModels); err != nil {
+		r.log.Error(ctx, "decode quarantined items failed", "error", err)
+		return nil, err
+	}
+
+	var items []*entities.QuarantinedItem
+	for _, quarantineModel := range quarantineModels {
+		item, err := quarantineModel.ToQuarantinedItem(false)
+		if err != nil {
+			r.log.Error(ctx, "convert quarantined item failed", "error", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// FindQuarantinedItemByID finds a quarantined item with its raw entity by its id, it returns nil if there is no such item
+func (r *FundMongo) FindQuarantinedItemByID(ctx context.Context, id string) (*entities.QuarantinedItem, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.log.Error(ctx, "invalid quarantined item id", "id", id, "error", err)
+		return nil, err
+	}
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_QUARANTINE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{{
+		Key:   "_id",
+		Value: objectID,
+	}}
+
+	var quarantineModel *models.QuarantineModel
+	if err := r.findOne(ctx, col, filter, &quarantineModel); err != nil {
+		return nil, err
+	}
+
+	if quarantineModel == nil {
+		return nil, nil
+	}
+
+	item, err := quarantineModel.ToQuarantinedItem(true)
+	if err != nil {
+		r.log.Error(ctx, "convert quarantined item failed", "id", id, "error", err)
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// UpdateQuarantinedItemStatus moves a quarantined item from a status to another, it is false if the item is not in the
+// from status. The status is compared and set in one update, so of concurrent approve and discard only one moves a
+// pending item.
+func (r *FundMongo) UpdateQuarantinedItemStatus(ctx context.Context, id string, from string, to string) (bool, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.write)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.log.Error(ctx, "invalid quarantined item id", "id", id, "error", err)
+		return false, err
+	}
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_QUARANTINE_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return false, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{
+		{Key: "_id", Value: objectID},
+		{Key: "status", Value: from},
+	}
+
+	update := bson.D{{
+		Key: "$set",
+		Value: bson.D{
+			{Key: "status", Value: to},
+			{Key: "modifiedAt", Value: time.Now().UTC().Unix()},
+		},
+	}}
+
+	res, err := col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.log.Error(ctx, "update one failed", "id", id, "error", err)
+		return false, err
+	}
+
+	if res.MatchedCount == 0 {
+		r.log.Warn(ctx, "quarantined item not found in status", "id", id, "status", from)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// FindLiveModifiedAt finds when the live document of a kind and a ticker was last stored, as a unix time.
+// It returns 0 if there is no such document.
+func (r *FundMongo) FindLiveModifiedAt(ctx context.Context, kind string, ticker string) (int64, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	collection, ok := kindCollections[kind]
+	if !ok {
+		r.log.Error(ctx, "unsupported quarantine kind", "kind", kind)
+		return 0, fmt.Errorf("unsupported quarantine kind %q", kind)
+	}
+
+	colname, ok := r.conf.Colnames[collection]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return 0, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{{
+		Key:   "ticker",
+		Value: ticker,
+	}}
+
+	var doc struct {
+		ModifiedAt int64 `bson:"modifiedAt"`
+	}
+
+	opts := options.FindOne().SetProjection(bson.D{{Key: "modifiedAt", Value: 1}})
+
+	err := col.FindOne(ctx, filter, opts).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+
+	if err != nil {
+		r.log.Error(ctx, "find live document failed", "kind", kind, "ticker", ticker, "error", err)
+		return 0, err
+	}
+
+	return doc.ModifiedAt, nil
+}
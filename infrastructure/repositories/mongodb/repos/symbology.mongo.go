@@ -0,0 +1,63 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/identifier"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindFundOverviewBySymbol finds a live fund overview by a ticker of any vendor format, an ISIN or a SEDOL,
+// it returns nil if there is no such fund
+func (r *FundMongo) FindFundOverviewBySymbol(ctx context.Context, symbol string) (*entities.FundOverviewRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_OVERVIEW_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	// vendor tickers are matched as given, identifiers in upper case. The primary key is the yahoo ticker.
+	symbol = strings.TrimSpace(symbol)
+	symbols := []string{symbol, identifier.Normalize(symbol), ticker.ParseExchangeTicker(symbol), ticker.NormalizeYahooTicker(symbol)}
+
+	matches := bson.A{
+		bson.D{{
+			Key:   "ticker",
+			Value: bson.D{{Key: "$in", Value: symbols}},
+		}},
+	}
+	for _, field := range models.SymbologyFields {
+		matches = append(matches, bson.D{{
+			Key:   field,
+			Value: bson.D{{Key: "$in", Value: symbols}},
+		}})
+	}
+
+	filter := append(liveFilter(), bson.E{
+		Key:   "$or",
+		Value: matches,
+	})
+
+	var fundOverviewModel *models.FundOverviewModel
+	if err := r.findOne(ctx, col, filter, &fundOverviewModel); err != nil {
+		return nil, err
+	}
+
+	if fundOverviewModel == nil {
+		return nil, nil
+	}
+
+	return fundOverviewModel.ToFundOverviewRecord(), nil
+}
@@ -0,0 +1,49 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/config"
+)
+
+// maxTimeout is the longest timeout accepted for any operation
+const maxTimeout = time.Hour
+
+// timeouts struct holds the timeout of every operation class
+type timeouts struct {
+	connect time.Duration
+	read    time.Duration
+	write   time.Duration
+	bulk    time.Duration
+}
+
+// newTimeouts converts and validates the millisecond timeouts of the mongo config
+func newTimeouts(conf *config.MongoConfig) (*timeouts, error) {
+	t := &timeouts{}
+
+	for _, timeout := range []struct {
+		name string
+		ms   uint64
+		d    *time.Duration
+	}{
+		{name: "connect", ms: conf.ConnectTimeoutMS, d: &t.connect},
+		{name: "read", ms: conf.ReadTimeoutMS, d: &t.read},
+		{name: "write", ms: conf.WriteTimeoutMS, d: &t.write},
+		{name: "bulk", ms: conf.BulkTimeoutMS, d: &t.bulk},
+	} {
+		if timeout.ms == 0 || timeout.ms > uint64(maxTimeout/time.Millisecond) {
+			return nil, fmt.Errorf("invalid %s timeout %dms, must be between 1ms and %v", timeout.name, timeout.ms, maxTimeout)
+		}
+
+		*timeout.d = time.Duration(timeout.ms) * time.Millisecond
+	}
+
+	return t, nil
+}
+
+// createContext create a new context with timeout. A caller deadline earlier than the timeout is kept.
+func createContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, timeout)
+}
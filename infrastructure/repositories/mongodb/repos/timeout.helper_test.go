@@ -0,0 +1,173 @@
+package repos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/config"
+)
+
+func TestNewTimeouts(t *testing.T) {
+	valid := config.MongoConfig{
+		ConnectTimeoutMS: 30000,
+		ReadTimeoutMS:    60000,
+		WriteTimeoutMS:   45000,
+		BulkTimeoutMS:    360000,
+	}
+
+	got, err := newTimeouts(&valid)
+	if err != nil {
+		t.Fatalf("newTimeouts() unexpected error: %v", err)
+	}
+
+	want := timeouts{
+		connect: 30 * time.Second,
+		read:    time.Minute,
+		write:   45 * time.Second,
+		bulk:    6 * time.Minute,
+	}
+
+	if *got != want {
+		t.Errorf("newTimeouts() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestNewTimeoutsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(conf *config.MongoConfig)
+	}{
+		{
+			name:   "missing connect timeout",
+			modify: func(conf *config.MongoConfig) { conf.ConnectTimeoutMS = 0 },
+		},
+		{
+			name:   "missing read timeout",
+			modify: func(conf *config.MongoConfig) { conf.ReadTimeoutMS = 0 },
+		},
+		{
+			name:   "missing write timeout",
+			modify: func(conf *config.MongoConfig) { conf.WriteTimeoutMS = 0 },
+		},
+		{
+			name:   "missing bulk timeout",
+			modify: func(conf *config.MongoConfig) { conf.BulkTimeoutMS = 0 },
+		},
+		{
+			name:   "bulk timeout longer than an hour",
+			modify: func(conf *config.MongoConfig) { conf.BulkTimeoutMS = uint64(time.Hour/time.Millisecond) + 1 },
+		},
+		{
+			name:   "timeout overflowing a duration",
+			modify: func(conf *config.MongoConfig) { conf.ReadTimeoutMS = ^uint64(0) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := config.MongoConfig{
+				ConnectTimeoutMS: 1000,
+				ReadTimeoutMS:    1000,
+				WriteTimeoutMS:   1000,
+				BulkTimeoutMS:    1000,
+			}
+			tt.modify(&conf)
+
+			if _, err := newTimeouts(&conf); err == nil {
+				t.Errorf("newTimeouts() expected an error")
+			}
+		})
+	}
+}
+
+func TestCreateContextUsesTimeout(t *testing.T) {
+	// 360000ms used to become 360000 * 1ms * 1ms, about 4 days
+	conf := config.MongoConfig{
+		ConnectTimeoutMS: 360000,
+		ReadTimeoutMS:    360000,
+		WriteTimeoutMS:   360000,
+		BulkTimeoutMS:    360000,
+	}
+
+	timeouts, err := newTimeouts(&conf)
+	if err != nil {
+		t.Fatalf("newTimeouts() unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	ctx, cancel := createContext(context.Background(), timeouts.read)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("createContext() has no deadline")
+	}
+
+	if remaining := deadline.Sub(start); remaining < 6*time.Minute-time.Second || remaining > 6*time.Minute+time.Second {
+		t.Errorf("createContext() deadline in %v, want about 6m0s", remaining)
+	}
+}
+
+func TestCreateContextKeepsEarlierCallerDeadline(t *testing.T) {
+	parent, parentCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer parentCancel()
+
+	parentDeadline, _ := parent.Deadline()
+
+	ctx, cancel := createContext(parent, time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("createContext() has no deadline")
+	}
+
+	if !deadline.Equal(parentDeadline) {
+		t.Errorf("createContext() deadline = %v, want caller deadline %v", deadline, parentDeadline)
+	}
+
+	select {
+	case <-ctx.Done():
+		if ctx.Err() != context.DeadlineExceeded {
+			t.Errorf("createContext() error = %v, want %v", ctx.Err(), context.DeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("createContext() did not expire with the caller deadline")
+	}
+}
+
+func TestCreateContextShortensLaterCallerDeadline(t *testing.T) {
+	parent, parentCancel := context.WithTimeout(context.Background(), time.Hour)
+	defer parentCancel()
+
+	ctx, cancel := createContext(parent, time.Second)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatalf("createContext() has no deadline")
+	}
+
+	if remaining := time.Until(deadline); remaining > time.Second {
+		t.Errorf("createContext() deadline in %v, want at most 1s", remaining)
+	}
+}
+
+func TestCreateContextFollowsCallerCancellation(t *testing.T) {
+	parent, parentCancel := context.WithCancel(context.Background())
+
+	ctx, cancel := createContext(parent, time.Minute)
+	defer cancel()
+
+	parentCancel()
+
+	select {
+	case <-ctx.Done():
+		if ctx.Err() != context.Canceled {
+			t.Errorf("createContext() error = %v, want %v", ctx.Err(), context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("createContext() was not canceled with the caller")
+	}
+}
@@ -0,0 +1,68 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindTopFundHolding finds the holding of a fund with its n largest stocks only, stocks are stored sorted by rank.
+// It returns nil if there is no such fund.
+func (r *FundMongo) FindTopFundHolding(ctx context.Context, ticker string, n int) (*entities.FundHoldingRecord, error) {
+	// create new context for the query
+	ctx, cancel := createContext(ctx, r.timeouts.read)
+	defer cancel()
+
+	// what collection we are going to use
+	colname, ok := r.conf.Colnames[consts.VANGUARD_FUND_HOLDING_COLLECTION]
+	if !ok {
+		r.log.Error(ctx, "cannot find collection name")
+		return nil, fmt.Errorf("cannot find collection name")
+	}
+	col := r.db.Collection(colname)
+
+	filter := bson.D{
+		{
+			Key:   "ticker",
+			Value: ticker,
+		},
+		{
+			Key:   "deleted",
+			Value: false,
+		},
+	}
+
+	// bond holdings are not needed, the stocks are cut to the top n in the database
+	projection := bson.D{
+		{
+			Key:   "bondHolding",
+			Value: 0,
+		},
+		{
+			Key: "stockHolding",
+			Value: bson.D{{
+				Key:   "$slice",
+				Value: n,
+			}},
+		},
+	}
+
+	var fundHoldingModel *models.FundHoldingModel
+	err := col.FindOne(ctx, filter, options.FindOne().SetProjection(projection)).Decode(&fundHoldingModel)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+
+	if err != nil {
+		r.log.Error(ctx, "find top fund holding failed", "error", err)
+		return nil, err
+	}
+
+	return fundHoldingModel.ToFundHoldingRecord(), nil
+}
@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// distributionDateFields are the distribution dates stored as timestamps from schema version 3, they were stored as scraped strings before
+var distributionDateFields = []string{
+	"distributionHistories.exDividendDate",
+	"distributionHistories.recordDate",
+	"distributionHistories.payableDate",
+}
+
+func init() {
+	migrations.Register(&migrations.Migration{
+		Collection:  consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION,
+		Version:     3,
+		Description: "store distribution dates as business date timestamps instead of strings",
+		Up: func(doc bson.M) error {
+			return convertFields(doc, stringToDate, distributionDateFields...)
+		},
+		Down: func(doc bson.M) error {
+			return convertFields(doc, dateToString, distributionDateFields...)
+		},
+	})
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// stringToDate converts a scraped date to a business date, an unparsable date is removed like the model drops it
+func stringToDate(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	date, err := datetime.ParseDate(s)
+	if err != nil || date == nil {
+		return nil, nil
+	}
+
+	return *date, nil
+}
+
+// dateToString converts a business date back to a RFC3339 string at midnight in Toronto
+func dateToString(value interface{}) (interface{}, error) {
+	var date time.Time
+	switch v := value.(type) {
+	case primitive.DateTime:
+		date = v.Time().UTC()
+	case time.Time:
+		date = v.UTC()
+	default:
+		return value, nil
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, datetime.Toronto).Format(time.RFC3339), nil
+}
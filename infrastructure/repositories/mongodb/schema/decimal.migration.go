@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// decimalFields are the money and percentage fields stored as Decimal128 from schema version 2, keyed by collection.
+// A path goes through arrays of sub documents, e.g. sectors.fundPercent is the fundPercent of every sector.
+var decimalFields = map[string][]string{
+	consts.VANGUARD_FUND_OVERVIEW_COLLECTION: {
+		"totalAssets", "yield12Month", "price", "managementFee", "merFee", "distYield", "distAmount",
+		"allocationStock", "allocationBond", "allocationCash",
+		"sectors.fundPercent", "countries.fundMktPercent", "countries.fundTnaPercent", "dividends.amount",
+	},
+	consts.VANGUARD_FUND_HOLDING_COLLECTION: {
+		"bondHolding.faceAmount", "bondHolding.marketValPercent", "bondHolding.marketValue", "bondHolding.rate",
+		"stockHolding.marketValPercent", "stockHolding.marketValue", "stockHolding.shares",
+	},
+	consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION: {
+		"distributionHistories.distributionAmount",
+	},
+	consts.VANGUARD_FUND_CHANGE_COLLECTION: {
+		"delta", "oldValue", "newValue",
+	},
+}
+
+// fundFeeFields are the fees of the fund list, they were stored as scraped strings before schema version 2
+var fundFeeFields = []string{"managementFee", "merFee"}
+
+func init() {
+	for collection, paths := range decimalFields {
+		paths := paths
+		migrations.Register(&migrations.Migration{
+			Collection:  collection,
+			Version:     2,
+			Description: "store money and percentage fields as Decimal128",
+			Up: func(doc bson.M) error {
+				return convertFields(doc, numberToDecimal, paths...)
+			},
+			Down: func(doc bson.M) error {
+				return convertFields(doc, decimalToDouble, paths...)
+			},
+		})
+	}
+
+	migrations.Register(&migrations.Migration{
+		Collection:  consts.VANGUARD_FUND_LIST_COLLECTION,
+		Version:     2,
+		Description: "store fees as Decimal128 instead of strings",
+		Up: func(doc bson.M) error {
+			return convertFields(doc, stringToDecimal, fundFeeFields...)
+		},
+		Down: func(doc bson.M) error {
+			return convertFields(doc, decimalToString, fundFeeFields...)
+		},
+	})
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// convertFunc converts a field value, a nil value removes the field
+type convertFunc func(value interface{}) (interface{}, error)
+
+// convertFields converts the fields of a document found at the paths, missing fields are skipped
+func convertFields(doc bson.M, convert convertFunc, paths ...string) error {
+	for _, path := range paths {
+		if err := convertPath(doc, strings.Split(path, "."), convert); err != nil {
+			return fmt.Errorf("convert %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// convertPath walks a value down the keys of a path and converts the last field
+func convertPath(value interface{}, keys []string, convert convertFunc) error {
+	switch v := value.(type) {
+	case bson.M:
+		field, ok := v[keys[0]]
+		if !ok {
+			return nil
+		}
+
+		if len(keys) > 1 {
+			return convertPath(field, keys[1:], convert)
+		}
+
+		converted, err := convert(field)
+		if err != nil {
+			return err
+		}
+
+		if converted == nil {
+			delete(v, keys[0])
+		} else {
+			v[keys[0]] = converted
+		}
+	case primitive.D:
+		for i, elem := range v {
+			if elem.Key != keys[0] {
+				continue
+			}
+
+			if len(keys) > 1 {
+				return convertPath(elem.Value, keys[1:], convert)
+			}
+
+			converted, err := convert(elem.Value)
+			if err != nil {
+				return err
+			}
+
+			// a removed field is written as null which the models read as zero
+			v[i].Value = converted
+			return nil
+		}
+	case primitive.A:
+		for _, item := range v {
+			if err := convertPath(item, keys, convert); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// numberToDecimal converts a double or an integer to a decimal, other values such as a fund name are kept
+func numberToDecimal(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	case int32:
+		return decimal.NewFromInt(int64(v)), nil
+	case int64:
+		return decimal.NewFromInt(v), nil
+	default:
+		return value, nil
+	}
+}
+
+// stringToDecimal converts a scraped string to a decimal, an unparsable string is removed like the model drops it
+// and "0" is kept as a 0% fee
+func stringToDecimal(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return numberToDecimal(value)
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil, nil
+	}
+
+	return d, nil
+}
+
+// decimalToDouble converts a Decimal128 back to a double
+func decimalToDouble(value interface{}) (interface{}, error) {
+	d, ok := value.(primitive.Decimal128)
+	if !ok {
+		return value, nil
+	}
+
+	converted, err := decimal.NewFromDecimal128(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return converted.Float64(), nil
+}
+
+// decimalToString converts a Decimal128 back to a string
+func decimalToString(value interface{}) (interface{}, error) {
+	d, ok := value.(primitive.Decimal128)
+	if !ok {
+		return value, nil
+	}
+
+	converted, err := decimal.NewFromDecimal128(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return converted.String(), nil
+}
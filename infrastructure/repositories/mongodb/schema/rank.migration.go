@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	migrations.Register(&migrations.Migration{
+		Collection:  consts.VANGUARD_FUND_HOLDING_COLLECTION,
+		Version:     7,
+		Description: "store the stock holdings sorted by weight with their rank",
+		Up: func(doc bson.M) error {
+			var stocks []*models.SectorWeightStockModel
+			if err := decodeField(doc, "stockHolding", &stocks); err != nil {
+				return err
+			}
+
+			if len(stocks) == 0 {
+				return nil
+			}
+
+			doc["stockHolding"] = models.RankStocks(stocks)
+
+			return nil
+		},
+		Down: func(doc bson.M) error {
+			var stocks []*models.SectorWeightStockModel
+			if err := decodeField(doc, "stockHolding", &stocks); err != nil {
+				return err
+			}
+
+			if len(stocks) == 0 {
+				return nil
+			}
+
+			// the stocks are left in weight order, which is a valid order of the previous schema
+			for _, stock := range stocks {
+				stock.Rank = 0
+			}
+			doc["stockHolding"] = stocks
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func init() {
+	migrations.Register(&migrations.Migration{
+		Collection:  consts.VANGUARD_FUND_OVERVIEW_COLLECTION,
+		Version:     5,
+		Description: "store the country exposure rolled up to regions and market classifications",
+		Up: func(doc bson.M) error {
+			var countries []*models.CountryBreakdownModel
+			if err := decodeField(doc, "countries", &countries); err != nil {
+				return err
+			}
+
+			if len(countries) == 0 {
+				return nil
+			}
+
+			doc["regions"] = models.NewRegionModels(countries)
+			doc["markets"] = models.NewMarketModels(countries)
+
+			return nil
+		},
+		Down: func(doc bson.M) error {
+			delete(doc, "regions")
+			delete(doc, "markets")
+
+			return nil
+		},
+	})
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// decodeField decodes a field of a document into a model, out is left as is if the field is missing
+func decodeField(doc bson.M, key string, out interface{}) error {
+	value, ok := doc[key]
+	if !ok {
+		return nil
+	}
+
+	t, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return err
+	}
+
+	return bson.RawValue{Type: t, Value: data}.Unmarshal(out)
+}
@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// symbologyCollections are the collections whose documents store the symbology from schema version 4
+var symbologyCollections = map[string]bool{
+	consts.VANGUARD_FUND_LIST_COLLECTION:     true,
+	consts.VANGUARD_FUND_OVERVIEW_COLLECTION: true,
+}
+
+func init() {
+	// overviews are looked up by any symbol of their symbology
+	migrations.RegisterLookupIndex(consts.VANGUARD_FUND_OVERVIEW_COLLECTION, models.SymbologyFields...)
+
+	for _, collection := range migrations.MigratableCollections {
+		withSymbology := symbologyCollections[collection]
+		migrations.Register(&migrations.Migration{
+			Collection:  collection,
+			Version:     4,
+			Description: "key U.S. dollar share classes by their yahoo ticker, e.g. VBG-U.TO instead of VBG.U.TO, and store the symbology",
+			Up: func(doc bson.M) error {
+				yahooTicker, ok := doc["ticker"].(string)
+				if !ok {
+					return nil
+				}
+
+				doc["ticker"] = ticker.NormalizeYahooTicker(yahooTicker)
+				if withSymbology {
+					isin, _ := doc["isin"].(string)
+					sedol, _ := doc["sedol"].(string)
+					doc["symbology"] = models.NewSymbologyModel(ticker.ParseExchangeTicker(yahooTicker), isin, sedol)
+				}
+
+				return nil
+			},
+			Down: func(doc bson.M) error {
+				yahooTicker, ok := doc["ticker"].(string)
+				if !ok {
+					return nil
+				}
+
+				// the exchange suffix is kept as stored, only the share class goes back to a dot
+				exchangeTicker, exchangeSuffix := ticker.SplitYahooTicker(yahooTicker)
+				doc["ticker"] = exchangeTicker + exchangeSuffix
+				delete(doc, "symbology")
+
+				return nil
+			},
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/infrastructure/repositories/mongodb/models"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/migrations"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/lookup"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/taxonomy"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// legacySectorCodes are the flat sector codes of schema version 5 and before keyed by sector name, others were OTH
+var legacySectorCodes = map[string]string{
+	"Industrials":            "IND",
+	"Producer Durables":      "IND",
+	"Financials":             "FIN",
+	"Financial Services":     "FIN",
+	"Health Care":            "HEC",
+	"Consumer Discretionary": "CND",
+	"Consumer Staples":       "CNS",
+	"Consumer Goods":         "CNS",
+	"Information Technology": "TEC",
+	"Technology":             "TEC",
+	"Materials":              "MTL",
+	"Basic Materials":        "MTL",
+	"Materials & Processing": "MTL",
+	"Energy":                 "ENG",
+	"Oil & Gas":              "ENG",
+	"Utilities":              "UTL",
+	"Communication Services": "TEL",
+	"Telecommunications":     "TEL",
+	"Real Estate":            "RLE",
+}
+
+func init() {
+	migrations.Register(&migrations.Migration{
+		Collection:  consts.VANGUARD_FUND_OVERVIEW_COLLECTION,
+		Version:     6,
+		Description: "classify sectors under the sector taxonomy in effect when the overview was scraped",
+		Up: func(doc bson.M) error {
+			var sectors []*models.SectorBreakdownModel
+			if err := decodeField(doc, "sectors", &sectors); err != nil {
+				return err
+			}
+
+			sectorTaxonomy := taxonomy.VersionAt(scrapedAt(doc))
+			for _, sector := range sectors {
+				// an unknown name stays OTH as before
+				sector.SectorCode, sector.IndustryGroupCode, _ = models.GetSectorCodes(sector.SectorName, sectorTaxonomy)
+			}
+
+			doc["sectorTaxonomy"] = sectorTaxonomy
+			if sectors != nil {
+				doc["sectors"] = sectors
+			}
+
+			return nil
+		},
+		Down: func(doc bson.M) error {
+			var sectors []*models.SectorBreakdownModel
+			if err := decodeField(doc, "sectors", &sectors); err != nil {
+				return err
+			}
+
+			legacyCodes := make(map[string]string)
+			for name, code := range legacySectorCodes {
+				legacyCodes[lookup.NormalizeName(name)] = code
+			}
+
+			for _, sector := range sectors {
+				sector.SectorCode = "OTH"
+				if code, ok := legacyCodes[lookup.NormalizeName(sector.SectorName)]; ok {
+					sector.SectorCode = code
+				}
+				sector.IndustryGroupCode = ""
+			}
+
+			delete(doc, "sectorTaxonomy")
+			if sectors != nil {
+				doc["sectors"] = sectors
+			}
+
+			return nil
+		},
+	})
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// scrapedAt gets when a document was last scraped, now if it is not stamped
+func scrapedAt(doc bson.M) time.Time {
+	for _, key := range []string{"modifiedAt", "createdAt"} {
+		switch v := doc[key].(type) {
+		case int64:
+			return time.Unix(v, 0).UTC()
+		case int32:
+			return time.Unix(int64(v), 0).UTC()
+		}
+	}
+
+	return time.Now().UTC()
+}
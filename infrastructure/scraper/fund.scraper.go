@@ -3,6 +3,7 @@ package scraper
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"time"
 
@@ -18,6 +19,9 @@ import (
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/funds"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/holding"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/overview"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/performance"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/validation"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/runid"
 )
 
 // FundScraper struct
@@ -26,29 +30,35 @@ type FundScraper struct {
 	ScrapeFundHoldingJob      *colly.Collector
 	ScrapeFundOverviewJob     *colly.Collector
 	ScrapeFundDistributionJob *colly.Collector
+	ScrapeFundPerformanceJob  *colly.Collector
 	fundService               *funds.Service
 	holdingService            *holding.Service
 	overviewService           *overview.Service
 	distributionService       *distributions.Service
+	performanceService        *performance.Service
+	runID                     string
 	log                       logger.ContextLog
 }
 
 // NewFundScraper create new fund scraper
-func NewFundScraper(fundService *funds.Service, holdingService *holding.Service, overviewService *overview.Service, distributionService *distributions.Service, log logger.ContextLog) *FundScraper {
+func NewFundScraper(fundService *funds.Service, holdingService *holding.Service, overviewService *overview.Service, distributionService *distributions.Service, performanceService *performance.Service, log logger.ContextLog) *FundScraper {
 	scrapeFundListJob := newScraperJob()
 	scrapeFundHoldingJob := newScraperJob()
 	scrapeFundOverviewJob := newScraperJob()
 	scrapeFundDistributionJob := newScraperJob()
+	scrapeFundPerformanceJob := newScraperJob()
 
 	return &FundScraper{
 		ScrapeFundListJob:         scrapeFundListJob,
 		ScrapeFundHoldingJob:      scrapeFundHoldingJob,
 		ScrapeFundOverviewJob:     scrapeFundOverviewJob,
 		ScrapeFundDistributionJob: scrapeFundDistributionJob,
+		ScrapeFundPerformanceJob:  scrapeFundPerformanceJob,
 		fundService:               fundService,
 		holdingService:            holdingService,
 		overviewService:           overviewService,
 		distributionService:       distributionService,
+		performanceService:        performanceService,
 		log:                       log,
 	}
 }
@@ -90,11 +100,16 @@ func (s *FundScraper) configJobs() {
 
 	s.ScrapeFundDistributionJob.OnError(s.errorHandler)
 	s.ScrapeFundDistributionJob.OnResponse(s.processFundDistributionResponse)
+
+	s.ScrapeFundPerformanceJob.OnError(s.errorHandler)
+	s.ScrapeFundPerformanceJob.OnResponse(s.processFundPerformanceResponse)
 }
 
 // ScrapeAllVanguardFundsDetails scrape all Vanguard funds details
 func (s *FundScraper) ScrapeAllVanguardFundsDetails() {
-	ctx := context.Background()
+	s.runID = runid.New()
+	ctx := runid.NewContext(context.Background(), s.runID)
+	s.log.Info(ctx, "start scrape run", "runId", s.runID)
 
 	s.configJobs()
 
@@ -106,10 +121,12 @@ func (s *FundScraper) ScrapeAllVanguardFundsDetails() {
 	s.ScrapeFundHoldingJob.Wait()
 	s.ScrapeFundOverviewJob.Wait()
 	s.ScrapeFundDistributionJob.Wait()
+	s.ScrapeFundPerformanceJob.Wait()
 }
 
 // ScrapeAllVanguardFundsDetails scrape all Vanguard funds details
 func (s *FundScraper) ScrapeSingleFundsOverview(portID string, fundName string) {
+	s.runID = runid.New()
 	s.configJobs()
 
 	// scrape overview data
@@ -134,7 +151,7 @@ func (s *FundScraper) errorHandler(r *colly.Response, err error) {
 func (s *FundScraper) processFundListResponse(r *colly.Response) {
 	// create correlation if for processing fund list
 	id, _ := uuid.NewRandom()
-	ctx := corid.NewContext(context.Background(), id)
+	ctx := runid.NewContext(corid.NewContext(context.Background(), id), s.runID)
 
 	// define anonymous struct to map fund data from fund list reponse
 	d := struct {
@@ -147,11 +164,19 @@ func (s *FundScraper) processFundListResponse(r *colly.Response) {
 		return
 	}
 
+	var tickers []string
 	for key, fund := range d.Funds {
 		if fund.Ticker != "" {
+			tickers = append(tickers, fund.Ticker)
+
+			// a fund held back by validation keeps its stored document, its details are still scraped
 			if err := s.fundService.CreateFund(ctx, &fund); err != nil {
-				s.log.Error(ctx, "create fund failed", "portId", key, "error", err)
-				continue
+				if !errors.Is(err, validation.ErrQuarantined) && !errors.Is(err, validation.ErrRejected) {
+					s.log.Error(ctx, "create fund failed", "portId", key, "error", err)
+					continue
+				}
+
+				s.log.Warn(ctx, "fund held back by validation", "portId", key, "error", err)
 			}
 
 			// scrape overview data
@@ -174,8 +199,27 @@ func (s *FundScraper) processFundListResponse(r *colly.Response) {
 			distributionCTX.Put("portId", key)
 			distributionCTX.Put("ticker", fund.Ticker)
 			s.ScrapeFundDistributionJob.Request("GET", distributionURL, nil, distributionCTX, nil)
+
+			// scrape performance data
+			if config.ScrapeFundPerformance {
+				performanceURL := config.GetFundPerformanceURL(key, "F")
+				performanceCTX := colly.NewContext()
+				performanceCTX.Put("portId", key)
+				performanceCTX.Put("ticker", fund.Ticker)
+				s.ScrapeFundPerformanceJob.Request("GET", performanceURL, nil, performanceCTX, nil)
+			}
 		}
 	}
+
+	// an empty fund list is more likely a bad response than every fund being delisted
+	if len(tickers) == 0 {
+		s.log.Warn(ctx, "fund list is empty")
+		return
+	}
+
+	if err := s.fundService.ReconcileFundList(ctx, tickers); err != nil {
+		s.log.Error(ctx, "reconcile fund list failed", "error", err)
+	}
 }
 
 ///////////////////////////////////////////////////////////
@@ -185,7 +229,7 @@ func (s *FundScraper) processFundListResponse(r *colly.Response) {
 func (s *FundScraper) processFundOverviewResponse(r *colly.Response) {
 	// create correlation if for processing fund overview
 	id, _ := uuid.NewRandom()
-	ctx := corid.NewContext(context.Background(), id)
+	ctx := runid.NewContext(corid.NewContext(context.Background(), id), s.runID)
 
 	fundName := r.Request.Ctx.Get("fundName")
 
@@ -209,7 +253,7 @@ func (s *FundScraper) processFundOverviewResponse(r *colly.Response) {
 func (s *FundScraper) processFundHoldingResponse(r *colly.Response) {
 	// create correlation if for processing fund holding
 	id, _ := uuid.NewRandom()
-	ctx := corid.NewContext(context.Background(), id)
+	ctx := runid.NewContext(corid.NewContext(context.Background(), id), s.runID)
 
 	portID := r.Request.Ctx.Get("portId")
 	ticker := r.Request.Ctx.Get("ticker")
@@ -250,7 +294,7 @@ func (s *FundScraper) processFundHoldingResponse(r *colly.Response) {
 func (s *FundScraper) processFundDistributionResponse(r *colly.Response) {
 	// create correlation if for processing fund holding
 	id, _ := uuid.NewRandom()
-	ctx := corid.NewContext(context.Background(), id)
+	ctx := runid.NewContext(corid.NewContext(context.Background(), id), s.runID)
 
 	portID := r.Request.Ctx.Get("portId")
 	ticker := r.Request.Ctx.Get("ticker")
@@ -266,3 +310,28 @@ func (s *FundScraper) processFundDistributionResponse(r *colly.Response) {
 		s.log.Error(ctx, "failed to create fund distribution", "portId", portID, "ticker", ticker, "error", err)
 	}
 }
+
+///////////////////////////////////////////////////////////
+// Fund Performance Scraper
+///////////////////////////////////////////////////////////
+
+func (s *FundScraper) processFundPerformanceResponse(r *colly.Response) {
+	// create correlation if for processing fund performance
+	id, _ := uuid.NewRandom()
+	ctx := runid.NewContext(corid.NewContext(context.Background(), id), s.runID)
+
+	portID := r.Request.Ctx.Get("portId")
+	ticker := r.Request.Ctx.Get("ticker")
+
+	fundPerformance := &entities.FundPerformance{}
+	if err := json.Unmarshal(r.Body, fundPerformance); err != nil {
+		s.log.Error(ctx, "failed to parse fund performance response", "error", err)
+		return
+	}
+
+	fundPerformance.PortID = portID
+	fundPerformance.Ticker = ticker
+	if err := s.performanceService.CreateFundPerformance(ctx, fundPerformance); err != nil {
+		s.log.Error(ctx, "failed to create fund performance", "portId", portID, "ticker", ticker, "error", err)
+	}
+}
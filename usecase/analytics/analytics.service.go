@@ -0,0 +1,262 @@
+package analytics
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/tax"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// distribution struct is a parsed distribution history
+type distribution struct {
+	exDividendDate time.Time
+	amount         decimal.Decimal
+	special        bool
+	notional       bool
+}
+
+// GetDistributionAnalytics computes distribution analytics of the given funds as of a date, all funds if no ticker is given
+func (s *Service) GetDistributionAnalytics(ctx context.Context, tickers []string, asOf time.Time) ([]*entities.DistributionAnalytics, error) {
+	s.log.Info(ctx, "get distribution analytics", "tickers", tickers, "asOf", asOf)
+
+	asOf = datetime.BusinessDate(asOf)
+
+	var err error
+	var overviews []*entities.FundOverviewRecord
+	var fundDistributions []*entities.FundDistributionRecord
+
+	if len(tickers) == 0 {
+		if overviews, err = s.repo.FindFundOverviews(ctx); err != nil {
+			return nil, err
+		}
+
+		if fundDistributions, err = s.repo.FindFundDistributions(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		var yahooTickers []string
+		for _, t := range tickers {
+			yahooTickers = append(yahooTickers, ticker.NormalizeYahooTicker(t))
+		}
+
+		if overviews, err = s.repo.FindFundOverviewsByTickers(ctx, yahooTickers); err != nil {
+			return nil, err
+		}
+
+		if fundDistributions, err = s.repo.FindFundDistributionsByTickers(ctx, yahooTickers); err != nil {
+			return nil, err
+		}
+	}
+
+	distributionsByTicker := make(map[string]*entities.FundDistributionRecord)
+	for _, fundDistribution := range fundDistributions {
+		distributionsByTicker[fundDistribution.Ticker] = fundDistribution
+	}
+
+	var results []*entities.DistributionAnalytics
+	for _, overview := range overviews {
+		distributions := s.parseDistributions(ctx, distributionsByTicker[overview.Ticker])
+		results = append(results, computeDistributionAnalytics(overview, distributions, asOf))
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Ticker < results[j].Ticker
+	})
+
+	return results, nil
+}
+
+// parseDistributions parses distribution histories sorted by ex-dividend date, histories without a valid ex-dividend date are skipped
+func (s *Service) parseDistributions(ctx context.Context, fundDistribution *entities.FundDistributionRecord) []*distribution {
+	if fundDistribution == nil {
+		return nil
+	}
+
+	var distributions []*distribution
+	for _, history := range fundDistribution.DistributionHistories {
+		if history.ExDividendDate == nil {
+			s.log.Warn(ctx, "distribution has no ExDividendDate", "ticker", fundDistribution.Ticker, "type", history.Type, "distCode", history.DistCode)
+			continue
+		}
+
+		distributions = append(distributions, &distribution{
+			exDividendDate: *history.ExDividendDate,
+			amount:         history.DistributionAmount,
+			special:        tax.IsSpecialDistribution(history),
+			notional:       tax.IsNotional(tax.ClassifyDistribution(history)),
+		})
+	}
+
+	sort.Slice(distributions, func(i, j int) bool {
+		return distributions[i].exDividendDate.Before(distributions[j].exDividendDate)
+	})
+
+	return distributions
+}
+
+// computeDistributionAnalytics computes yields, growth, consistency and special distributions of a fund.
+// Notional (reinvested) distributions are not paid in cash so they are left out of the yields.
+func computeDistributionAnalytics(overview *entities.FundOverviewRecord, distributions []*distribution, asOf time.Time) *entities.DistributionAnalytics {
+	analytics := &entities.DistributionAnalytics{
+		Ticker:               overview.Ticker,
+		Name:                 overview.Name,
+		AsOf:                 asOf,
+		Price:                overview.Price,
+		DividendSchedule:     overview.DividendSchedule,
+		ReportedYield12Month: overview.Yield12Month,
+		ReportedDistYield:    overview.DistYield,
+	}
+
+	expectedPayments := consts.DividendSchedulePayments[strings.ToUpper(overview.DividendSchedule)]
+	analytics.ExpectedPaymentsPerYear = expectedPayments
+
+	oneYearAgo := asOf.AddDate(-1, 0, 0)
+	twoYearsAgo := asOf.AddDate(-2, 0, 0)
+
+	trailingRegular, previousRegular := decimal.Zero, decimal.Zero
+	var trailingRegularCount int
+	var lastRegular *distribution
+	years := make(map[int]*entities.YearDistribution)
+
+	for _, d := range distributions {
+		if d.exDividendDate.After(asOf) {
+			continue
+		}
+
+		year, ok := years[d.exDividendDate.Year()]
+		if !ok {
+			year = &entities.YearDistribution{Year: d.exDividendDate.Year()}
+			years[year.Year] = year
+		}
+
+		if d.special {
+			year.SpecialAmount = year.SpecialAmount.Add(d.amount)
+			analytics.SpecialAmount = analytics.SpecialAmount.Add(d.amount)
+		} else {
+			year.Payments++
+			year.RegularAmount = year.RegularAmount.Add(d.amount)
+			analytics.RegularAmount = analytics.RegularAmount.Add(d.amount)
+			lastRegular = d
+		}
+
+		inTrailingYear := d.exDividendDate.After(oneYearAgo)
+		inPreviousYear := !inTrailingYear && d.exDividendDate.After(twoYearsAgo)
+
+		if inTrailingYear && !d.notional {
+			analytics.TrailingAmount = analytics.TrailingAmount.Add(d.amount)
+		}
+
+		if !d.special && inTrailingYear {
+			trailingRegular = trailingRegular.Add(d.amount)
+			trailingRegularCount++
+		}
+
+		if !d.special && inPreviousYear {
+			previousRegular = previousRegular.Add(d.amount)
+		}
+	}
+
+	if total := analytics.RegularAmount.Add(analytics.SpecialAmount); total.IsPositive() {
+		analytics.SpecialPercent = analytics.SpecialAmount.Percent(total)
+	}
+
+	if overview.Price.IsPositive() {
+		analytics.TrailingYield = analytics.TrailingAmount.Percent(overview.Price)
+
+		// annualize the last regular distribution, fall back to the number of regular payments of the trailing year
+		paymentsPerYear := expectedPayments
+		if paymentsPerYear == 0 {
+			paymentsPerYear = trailingRegularCount
+		}
+
+		if lastRegular != nil {
+			analytics.ForwardYield = lastRegular.amount.Mul(decimal.NewFromInt(int64(paymentsPerYear))).Percent(overview.Price)
+		}
+	}
+
+	// growth rates are ratios rather than amounts so they stay floats
+	if previousRegular.IsPositive() {
+		analytics.GrowthRate = (trailingRegular.Div(previousRegular).Float64() - 1) * 100
+	}
+
+	for _, year := range years {
+		analytics.Years = append(analytics.Years, year)
+	}
+
+	sort.Slice(analytics.Years, func(i, j int) bool {
+		return analytics.Years[i].Year < analytics.Years[j].Year
+	})
+
+	analytics.AnnualGrowthRate = computeAnnualGrowthRate(analytics.Years, asOf)
+	analytics.PaymentConsistency = computePaymentConsistency(analytics.Years, expectedPayments, asOf)
+
+	return analytics
+}
+
+// computeAnnualGrowthRate computes the compound annual growth rate of regular distributions over complete calendar years
+func computeAnnualGrowthRate(years []*entities.YearDistribution, asOf time.Time) float64 {
+	var complete []*entities.YearDistribution
+	for _, year := range years {
+		if year.Year < asOf.Year() && year.RegularAmount.IsPositive() {
+			complete = append(complete, year)
+		}
+	}
+
+	if len(complete) < 2 {
+		return 0
+	}
+
+	first, last := complete[0], complete[len(complete)-1]
+	periods := float64(last.Year - first.Year)
+
+	return (math.Pow(last.RegularAmount.Div(first.RegularAmount).Float64(), 1/periods) - 1) * 100
+}
+
+// computePaymentConsistency computes the percentage of complete calendar years, after the first one, with the expected number of regular payments.
+// The first year is left out because the fund may have been launched during that year.
+func computePaymentConsistency(years []*entities.YearDistribution, expectedPayments int, asOf time.Time) float64 {
+	if expectedPayments == 0 || len(years) < 2 {
+		return 0
+	}
+
+	var complete, consistent int
+	for _, year := range years[1:] {
+		if year.Year >= asOf.Year() {
+			continue
+		}
+
+		complete++
+		if year.Payments == expectedPayments {
+			consistent++
+		}
+	}
+
+	if complete == 0 {
+		return 0
+	}
+
+	return float64(consistent) / float64(complete) * 100
+}
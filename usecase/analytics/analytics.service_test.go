@@ -0,0 +1,122 @@
+package analytics
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// fakeRepo serves one fund and records the tickers it was asked for
+type fakeRepo struct {
+	overview     *entities.FundOverviewRecord
+	distribution *entities.FundDistributionRecord
+	tickers      []string
+}
+
+func (r *fakeRepo) FindFundOverviews(ctx context.Context) ([]*entities.FundOverviewRecord, error) {
+	return []*entities.FundOverviewRecord{r.overview}, nil
+}
+
+func (r *fakeRepo) FindFundOverviewsByTickers(ctx context.Context, tickers []string) ([]*entities.FundOverviewRecord, error) {
+	r.tickers = tickers
+	return []*entities.FundOverviewRecord{r.overview}, nil
+}
+
+func (r *fakeRepo) FindFundDistributions(ctx context.Context) ([]*entities.FundDistributionRecord, error) {
+	return []*entities.FundDistributionRecord{r.distribution}, nil
+}
+
+func (r *fakeRepo) FindFundDistributionsByTickers(ctx context.Context, tickers []string) ([]*entities.FundDistributionRecord, error) {
+	return []*entities.FundDistributionRecord{r.distribution}, nil
+}
+
+func exDate(date string) *time.Time {
+	t, _ := time.Parse(datetime.DATE_LAYOUT, date)
+	return &t
+}
+
+func income(exDividendDate string, amount float64) *entities.DistributionHistoryRecord {
+	return &entities.DistributionHistoryRecord{Type: "Income", ExDividendDate: exDate(exDividendDate), DistributionAmount: decimal.NewFromFloat(amount)}
+}
+
+func TestGetDistributionAnalytics(t *testing.T) {
+	repo := &fakeRepo{
+		overview: &entities.FundOverviewRecord{Ticker: "VFV.TO", Price: decimal.NewFromInt(100), DividendSchedule: "Quarterly"},
+		distribution: &entities.FundDistributionRecord{
+			Ticker: "VFV.TO",
+			DistributionHistories: []*entities.DistributionHistoryRecord{
+				// launched late 2023, one payment missed in 2024
+				income("2023-12-28", 0.2),
+				income("2024-03-28", 0.25), income("2024-09-27", 0.25), income("2024-12-30", 0.25),
+				income("2025-03-28", 0.3), income("2025-06-27", 0.3), income("2025-09-29", 0.3), income("2025-12-30", 0.3),
+				{Type: "Capital Gain", DistDesc: "Special year-end distribution", ExDividendDate: exDate("2025-12-30"), DistributionAmount: decimal.NewFromFloat(0.5)},
+				{Type: "Capital Gain", DistDesc: "Reinvested capital gain", ExDividendDate: exDate("2025-12-30"), DistributionAmount: decimal.NewFromFloat(0.2)},
+				income("2026-03-27", 0.33), income("2026-06-29", 0.33), income("2026-09-28", 0.33),
+				// announced after the as of date
+				income("2026-12-29", 0.35),
+			},
+		},
+	}
+
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	asOf := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	results, err := NewService(repo, log).GetDistributionAnalytics(context.Background(), []string{"vfv"}, asOf)
+	if err != nil {
+		t.Fatalf("GetDistributionAnalytics error = %v", err)
+	}
+
+	if len(repo.tickers) != 1 || repo.tickers[0] != "VFV.TO" {
+		t.Errorf("repo was asked for %v, want [VFV.TO]", repo.tickers)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	got := results[0]
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		// the notional distribution is not paid in cash
+		{"trailing amount", got.TrailingAmount.Float64(), 0.3 + 0.5 + 0.99},
+		{"trailing yield", got.TrailingYield.Float64(), 1.79},
+		// the last regular payment times four quarters
+		{"forward yield", got.ForwardYield.Float64(), 1.32},
+		{"growth rate", got.GrowthRate, (1.29/1.15 - 1) * 100},
+		{"annual growth rate", got.AnnualGrowthRate, (math.Sqrt(1.2/0.2) - 1) * 100},
+		// 2025 has the four expected payments, 2024 only three
+		{"payment consistency", got.PaymentConsistency, 50},
+		{"special amount", got.SpecialAmount.Float64(), 0.7},
+		{"special percent", got.SpecialPercent.Float64(), 0.7 / (0.2 + 0.75 + 1.2 + 0.99 + 0.7) * 100},
+	}
+
+	for _, check := range checks {
+		if math.Abs(check.got-check.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+		}
+	}
+
+	if got.ExpectedPaymentsPerYear != 4 || len(got.Years) != 4 || got.Years[2].Payments != 4 || got.Years[3].Payments != 3 {
+		t.Errorf("expected payments %d, years %+v", got.ExpectedPaymentsPerYear, got.Years)
+	}
+}
+
+func TestComputeDistributionAnalyticsWithoutPrice(t *testing.T) {
+	distributions := []*distribution{{exDividendDate: time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC), amount: decimal.New(1, -1)}}
+	got := computeDistributionAnalytics(&entities.FundOverviewRecord{Ticker: "VAB.TO"}, distributions, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+
+	if got.TrailingAmount.String() != "0.1" || !got.TrailingYield.IsZero() || !got.ForwardYield.IsZero() {
+		t.Errorf("trailing amount/yield and forward yield = %v/%v/%v, want 0.1/0/0", got.TrailingAmount, got.TrailingYield, got.ForwardYield)
+	}
+}
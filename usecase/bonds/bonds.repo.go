@@ -0,0 +1,26 @@
+package bonds
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Bonds Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindFundHoldings(ctx context.Context) ([]*entities.FundHoldingRecord, error)
+	FindFundHoldingsByTickers(ctx context.Context, tickers []string) ([]*entities.FundHoldingRecord, error)
+}
+
+// Writer interface
+type Writer interface{}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
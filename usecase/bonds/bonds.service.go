@@ -0,0 +1,222 @@
+package bonds
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// UNKNOWN_BUCKET is the bucket of holdings missing the bucketed value
+const UNKNOWN_BUCKET = "Unknown"
+
+// bucket struct is a range of values below upTo and not below the upTo of the previous bucket
+type bucket struct {
+	name string
+	upTo float64
+}
+
+// couponBuckets bucket coupon rates in percent
+var couponBuckets = []bucket{
+	{"0-1%", 1},
+	{"1-2%", 2},
+	{"2-3%", 3},
+	{"3-4%", 4},
+	{"4-5%", 5},
+	{"5-6%", 6},
+	{"6%+", math.Inf(1)},
+}
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetBondAnalytics aggregates the bond holdings of bond and balanced funds, all funds if no ticker is given
+func (s *Service) GetBondAnalytics(ctx context.Context, tickers []string) ([]*entities.BondAnalytics, error) {
+	s.log.Info(ctx, "get bond analytics", "tickers", tickers)
+
+	var err error
+	var holdings []*entities.FundHoldingRecord
+
+	if len(tickers) == 0 {
+		if holdings, err = s.repo.FindFundHoldings(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		var yahooTickers []string
+		for _, t := range tickers {
+			yahooTickers = append(yahooTickers, ticker.NormalizeYahooTicker(t))
+		}
+
+		if holdings, err = s.repo.FindFundHoldingsByTickers(ctx, yahooTickers); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []*entities.BondAnalytics
+	for _, holding := range holdings {
+		if !strings.EqualFold(holding.AssetCode, consts.BOND) && !strings.EqualFold(holding.AssetCode, consts.BALANCED) {
+			continue
+		}
+
+		results = append(results, newBondAnalytics(ctx, s.log, holding))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Ticker < results[j].Ticker
+	})
+
+	return results, nil
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// newBondAnalytics aggregates the bond holdings of a fund, only the bond count is set if the holdings have no weight
+func newBondAnalytics(ctx context.Context, log logger.ContextLog, holding *entities.FundHoldingRecord) *entities.BondAnalytics {
+	analytics := &entities.BondAnalytics{
+		Ticker:    holding.Ticker,
+		AssetCode: holding.AssetCode,
+	}
+
+	var bonds []*entities.SectorWeightBondRecord
+	for _, bond := range holding.Bonds {
+		if bond != nil {
+			bonds = append(bonds, bond)
+		}
+	}
+
+	analytics.BondCount = len(bonds)
+	if len(bonds) == 0 {
+		return analytics
+	}
+
+	weights := bondWeights(bonds)
+
+	var total, weightedRate decimal.Decimal
+	for i, bond := range bonds {
+		total = total.Add(weights[i])
+		weightedRate = weightedRate.Add(weights[i].Mul(bond.Rate))
+	}
+
+	if total.IsZero() {
+		log.Warn(ctx, "bond holdings have no market value", "ticker", holding.Ticker, "bondCount", len(bonds))
+		return analytics
+	}
+
+	analytics.WeightedCoupon = weightedRate.Div(total).Round(4)
+
+	analytics.Coupons = bucketize(bonds, weights, total, names(couponBuckets), func(bond *entities.SectorWeightBondRecord) string {
+		return findBucket(couponBuckets, bond.Rate.Float64())
+	})
+
+	analytics.IssuerTypes = bucketize(bonds, weights, total, nil, func(bond *entities.SectorWeightBondRecord) string {
+		if issuerType := strings.TrimSpace(bond.Type); issuerType != "" {
+			return issuerType
+		}
+		return UNKNOWN_BUCKET
+	})
+
+	return analytics
+}
+
+// bondWeights gets the weight of every bond, its market value percent or its market value if no percent is published
+func bondWeights(bonds []*entities.SectorWeightBondRecord) []decimal.Decimal {
+	weights := make([]decimal.Decimal, len(bonds))
+
+	var totalPercent decimal.Decimal
+	for _, bond := range bonds {
+		totalPercent = totalPercent.Add(bond.MarketValPercent)
+	}
+
+	for i, bond := range bonds {
+		if totalPercent.IsZero() {
+			weights[i] = bond.MarketValue
+		} else {
+			weights[i] = bond.MarketValPercent
+		}
+	}
+
+	return weights
+}
+
+// bucketize sums bond weights by bucket as percents of the total, in the given bucket order or heaviest first if none
+func bucketize(bonds []*entities.SectorWeightBondRecord, weights []decimal.Decimal, total decimal.Decimal, order []string, bucketOf func(bond *entities.SectorWeightBondRecord) string) []*entities.BondBucket {
+	var buckets []*entities.BondBucket
+	bucketsByName := make(map[string]*entities.BondBucket)
+
+	for i, bond := range bonds {
+		name := bucketOf(bond)
+
+		b, ok := bucketsByName[name]
+		if !ok {
+			b = &entities.BondBucket{Bucket: name}
+			bucketsByName[name] = b
+			buckets = append(buckets, b)
+		}
+
+		b.Count++
+		b.Weight = b.Weight.Add(weights[i])
+	}
+
+	for _, b := range buckets {
+		b.Weight = b.Weight.Percent(total).Round(4)
+	}
+
+	if order == nil {
+		sort.SliceStable(buckets, func(i, j int) bool {
+			return buckets[i].Weight.GreaterThan(buckets[j].Weight)
+		})
+
+		return buckets
+	}
+
+	rank := make(map[string]int)
+	for i, name := range order {
+		rank[name] = i
+	}
+
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return rank[buckets[i].Bucket] < rank[buckets[j].Bucket]
+	})
+
+	return buckets
+}
+
+// findBucket finds the bucket of a value
+func findBucket(buckets []bucket, value float64) string {
+	for _, b := range buckets {
+		if value < b.upTo {
+			return b.name
+		}
+	}
+
+	return buckets[len(buckets)-1].name
+}
+
+// names gets the names of buckets in order
+func names(buckets []bucket) []string {
+	var bucketNames []string
+	for _, b := range buckets {
+		bucketNames = append(bucketNames, b.name)
+	}
+
+	return bucketNames
+}
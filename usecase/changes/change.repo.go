@@ -0,0 +1,25 @@
+package changes
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Change Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindFundChanges(ctx context.Context, since int64) ([]*entities.FundChange, error)
+}
+
+// Writer interface
+type Writer interface{}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
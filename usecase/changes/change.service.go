@@ -0,0 +1,60 @@
+package changes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetFundChanges gets changes detected since a time, optionally only of the given tickers and change types
+func (s *Service) GetFundChanges(ctx context.Context, since time.Time, tickers []string, changeTypes []string) ([]*entities.FundChange, error) {
+	s.log.Info(ctx, "get fund changes", "since", since, "tickers", tickers, "types", changeTypes)
+
+	storedChanges, err := s.repo.FindFundChanges(ctx, since.UTC().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	wantedTickers := make(map[string]bool)
+	for _, t := range tickers {
+		wantedTickers[ticker.NormalizeYahooTicker(t)] = true
+	}
+
+	wantedTypes := make(map[string]bool)
+	for _, changeType := range changeTypes {
+		wantedTypes[strings.ToUpper(strings.TrimSpace(changeType))] = true
+	}
+
+	var fundChanges []*entities.FundChange
+	for _, fundChange := range storedChanges {
+		if len(wantedTickers) > 0 && !wantedTickers[fundChange.Ticker] {
+			continue
+		}
+
+		if len(wantedTypes) > 0 && !wantedTypes[fundChange.Type] {
+			continue
+		}
+
+		fundChanges = append(fundChanges, fundChange)
+	}
+
+	return fundChanges, nil
+}
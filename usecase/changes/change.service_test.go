@@ -0,0 +1,47 @@
+package changes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+type fakeRepo struct {
+	changes []*entities.FundChange
+	since   int64
+}
+
+func (r *fakeRepo) FindFundChanges(ctx context.Context, since int64) ([]*entities.FundChange, error) {
+	r.since = since
+	return r.changes, nil
+}
+
+func TestGetFundChanges(t *testing.T) {
+	repo := &fakeRepo{changes: []*entities.FundChange{
+		{Ticker: "VFV.TO", Type: entities.CHANGE_TYPE_MER_FEE_CHANGED},
+		{Ticker: "VFV.TO", Type: entities.CHANGE_TYPE_NEW_DISTRIBUTION},
+		{Ticker: "VCN.TO", Type: entities.CHANGE_TYPE_MER_FEE_CHANGED},
+	}}
+
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.FixedZone("EDT", -4*60*60))
+	changes, err := NewService(repo, log).GetFundChanges(context.Background(), since, []string{"vfv"}, []string{" mer_fee_changed"})
+	if err != nil {
+		t.Fatalf("GetFundChanges error = %v", err)
+	}
+
+	if repo.since != since.Unix() {
+		t.Errorf("repo was asked for changes since %d, want %d", repo.since, since.Unix())
+	}
+
+	if len(changes) != 1 || changes[0] != repo.changes[0] {
+		t.Errorf("changes = %+v, want the VFV.TO MER change only", changes)
+	}
+}
@@ -16,6 +16,7 @@ type Reader interface{}
 // Writer interface
 type Writer interface {
 	InsertFundDistribution(ctx context.Context, fundDistribution *entities.FundDistribution) error
+	InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error
 }
 
 // Repo interface
@@ -0,0 +1,26 @@
+package dividends
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Dividend Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindFundOverviews(ctx context.Context) ([]*entities.FundOverviewRecord, error)
+	FindFundDistributions(ctx context.Context) ([]*entities.FundDistributionRecord, error)
+}
+
+// Writer interface
+type Writer interface{}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
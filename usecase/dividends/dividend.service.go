@@ -0,0 +1,201 @@
+package dividends
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/calendar"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+)
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetDividendCalendar lists distributions of all funds with an ex-dividend date between from and to.
+// Distributions with an ex-dividend date on or after asOf are upcoming, the others are past.
+// When a fund has no known upcoming distribution, the next ex-dividend date is projected from
+// the last known one and the fund dividend schedule.
+func (s *Service) GetDividendCalendar(ctx context.Context, asOf, from, to time.Time) (*entities.DividendCalendar, error) {
+	s.log.Info(ctx, "get dividend calendar", "asOf", asOf, "from", from, "to", to)
+
+	overviews, err := s.repo.FindFundOverviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	distributions, err := s.repo.FindFundDistributions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overviewsByTicker := make(map[string]*entities.FundOverviewRecord)
+	for _, overview := range overviews {
+		overviewsByTicker[overview.Ticker] = overview
+	}
+
+	asOf = datetime.BusinessDate(asOf)
+	calendar := &entities.DividendCalendar{
+		AsOf: asOf,
+	}
+
+	for _, distribution := range distributions {
+		overview := overviewsByTicker[distribution.Ticker]
+		events := s.newDividendEvents(ctx, distribution, overview)
+
+		var last *entities.DividendEvent
+		for _, event := range events {
+			if last == nil || event.ExDividendDate.After(*last.ExDividendDate) {
+				last = event
+			}
+
+			if event.ExDividendDate.Before(from) || event.ExDividendDate.After(to) {
+				continue
+			}
+
+			if event.ExDividendDate.Before(asOf) {
+				calendar.Past = append(calendar.Past, event)
+			} else {
+				calendar.Upcoming = append(calendar.Upcoming, event)
+			}
+		}
+
+		// project the next ex-dividend date if the next distribution has not been announced yet
+		if last != nil && last.ExDividendDate.Before(asOf) {
+			projected := projectNextEvent(last, asOf)
+			if projected != nil && !projected.ExDividendDate.After(to) {
+				calendar.Upcoming = append(calendar.Upcoming, projected)
+			}
+		}
+	}
+
+	sortEvents(calendar.Upcoming, false)
+	sortEvents(calendar.Past, true)
+
+	return calendar, nil
+}
+
+// newDividendEvents creates dividend events from distribution histories, histories without a valid ex-dividend date are skipped
+func (s *Service) newDividendEvents(ctx context.Context, distribution *entities.FundDistributionRecord, overview *entities.FundOverviewRecord) []*entities.DividendEvent {
+	var events []*entities.DividendEvent
+
+	for _, history := range distribution.DistributionHistories {
+		if history.ExDividendDate == nil {
+			s.log.Warn(ctx, "distribution has no ExDividendDate", "ticker", distribution.Ticker, "type", history.Type, "distCode", history.DistCode)
+			continue
+		}
+
+		event := &entities.DividendEvent{
+			Ticker:         distribution.Ticker,
+			Type:           history.Type,
+			Amount:         history.DistributionAmount,
+			ExDividendDate: history.ExDividendDate,
+			RecordDate:     history.RecordDate,
+			PayableDate:    history.PayableDate,
+			DistDesc:       history.DistDesc,
+			DistCode:       history.DistCode,
+		}
+
+		if overview != nil {
+			event.Name = overview.Name
+			event.DividendSchedule = overview.DividendSchedule
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// projectNextEvent projects the first ex-dividend date on or after asOf from the last known distribution
+func projectNextEvent(last *entities.DividendEvent, asOf time.Time) *entities.DividendEvent {
+	payments, ok := consts.DividendSchedulePayments[strings.ToUpper(last.DividendSchedule)]
+	if !ok {
+		return nil
+	}
+	months := 12 / payments
+
+	var next time.Time
+	for i := 1; ; i++ {
+		// an ex-dividend date falling on a weekend or a holiday moves to the business day before it
+		next = addMonths(*last.ExDividendDate, i*months)
+		if !calendar.IsBusinessDay(next) {
+			next = calendar.PreviousBusinessDay(next)
+		}
+
+		if !next.Before(asOf) {
+			break
+		}
+	}
+
+	projected := &entities.DividendEvent{
+		Ticker:           last.Ticker,
+		Name:             last.Name,
+		DividendSchedule: last.DividendSchedule,
+		Type:             last.Type,
+		Amount:           last.Amount,
+		ExDividendDate:   &next,
+		DistDesc:         last.DistDesc,
+		DistCode:         last.DistCode,
+		Projected:        true,
+	}
+
+	// keep the same gap between ex-dividend date and payable date as the last distribution
+	if last.PayableDate != nil {
+		payableDate := next.Add(last.PayableDate.Sub(*last.ExDividendDate))
+		if !calendar.IsBusinessDay(payableDate) {
+			payableDate = calendar.NextBusinessDay(payableDate)
+		}
+		projected.PayableDate = &payableDate
+	}
+
+	return projected
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// addMonths adds months to a date, the day is clamped to the last day of the resulting month
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, t.Location())
+}
+
+// sortEvents sorts events by ex-dividend date then ticker
+func sortEvents(events []*entities.DividendEvent, descending bool) {
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+
+		if !a.ExDividendDate.Equal(*b.ExDividendDate) {
+			if descending {
+				return a.ExDividendDate.After(*b.ExDividendDate)
+			}
+			return a.ExDividendDate.Before(*b.ExDividendDate)
+		}
+
+		return a.Ticker < b.Ticker
+	})
+}
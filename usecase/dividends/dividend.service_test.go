@@ -0,0 +1,106 @@
+package dividends
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+type fakeRepo struct {
+	overviews     []*entities.FundOverviewRecord
+	distributions []*entities.FundDistributionRecord
+}
+
+func (r *fakeRepo) FindFundOverviews(ctx context.Context) ([]*entities.FundOverviewRecord, error) {
+	return r.overviews, nil
+}
+
+func (r *fakeRepo) FindFundDistributions(ctx context.Context) ([]*entities.FundDistributionRecord, error) {
+	return r.distributions, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func day(year int, month time.Month, d int) *time.Time {
+	t := date(year, month, d)
+	return &t
+}
+
+func TestGetDividendCalendar(t *testing.T) {
+	repo := &fakeRepo{
+		overviews: []*entities.FundOverviewRecord{
+			{Ticker: "VFV.TO", Name: "Vanguard S&P 500 Index ETF", DividendSchedule: "Quarterly"},
+			{Ticker: "VDY.TO", Name: "Vanguard FTSE Canadian High Dividend Yield Index ETF", DividendSchedule: "Monthly"},
+		},
+		distributions: []*entities.FundDistributionRecord{
+			{
+				Ticker: "VFV.TO",
+				DistributionHistories: []*entities.DistributionHistoryRecord{
+					{Type: "Income", DistributionAmount: decimal.RequireFromString("0.2"), ExDividendDate: day(2026, 6, 30), PayableDate: day(2026, 7, 8)},
+					{Type: "Income", DistributionAmount: decimal.RequireFromString("0.19"), ExDividendDate: day(2026, 3, 31)},
+					{Type: "Income", DistributionAmount: decimal.RequireFromString("0.18")},
+				},
+			},
+			{
+				Ticker: "VDY.TO",
+				DistributionHistories: []*entities.DistributionHistoryRecord{
+					{Type: "Income", DistributionAmount: decimal.RequireFromString("0.16"), ExDividendDate: day(2026, 10, 27)},
+				},
+			},
+		},
+	}
+
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	asOf := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	calendar, err := NewService(repo, log).GetDividendCalendar(context.Background(), asOf, date(2026, 4, 1), date(2026, 12, 31))
+	if err != nil {
+		t.Fatalf("GetDividendCalendar error = %v", err)
+	}
+
+	if !calendar.AsOf.Equal(date(2026, 10, 19)) {
+		t.Errorf("as of = %v, want start of 2026-10-19", calendar.AsOf)
+	}
+
+	// the march distribution is out of range and the one without an ex-dividend date is skipped
+	if len(calendar.Past) != 1 || calendar.Past[0].Amount.String() != "0.2" || calendar.Past[0].Name != "Vanguard S&P 500 Index ETF" {
+		t.Fatalf("past = %+v, want the june VFV distribution", calendar.Past)
+	}
+
+	if len(calendar.Upcoming) != 2 {
+		t.Fatalf("got %d upcoming events, want 2", len(calendar.Upcoming))
+	}
+
+	announced, projected := calendar.Upcoming[0], calendar.Upcoming[1]
+	if announced.Ticker != "VDY.TO" || announced.Projected {
+		t.Errorf("first upcoming = %s projected %v, want the announced VDY.TO distribution", announced.Ticker, announced.Projected)
+	}
+
+	// one quarter after june 30 is before the as of date, two quarters after is december 30
+	if projected.Ticker != "VFV.TO" || !projected.Projected || !projected.ExDividendDate.Equal(date(2026, 12, 30)) {
+		t.Errorf("projected = %s on %v, want VFV.TO on 2026-12-30", projected.Ticker, projected.ExDividendDate)
+	}
+
+	if projected.PayableDate == nil || !projected.PayableDate.Equal(date(2027, 1, 7)) {
+		t.Errorf("projected payable date = %v, want 2027-01-07", projected.PayableDate)
+	}
+}
+
+func TestAddMonthsClampsDay(t *testing.T) {
+	if got := addMonths(date(2026, 1, 31), 1); !got.Equal(date(2026, 2, 28)) {
+		t.Errorf("addMonths(2026-01-31, 1) = %v, want 2026-02-28", got)
+	}
+
+	if got := addMonths(date(2026, 11, 30), 3); !got.Equal(date(2027, 2, 28)) {
+		t.Errorf("addMonths(2026-11-30, 3) = %v, want 2027-02-28", got)
+	}
+}
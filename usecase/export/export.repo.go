@@ -0,0 +1,28 @@
+package export
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Export Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindFunds(ctx context.Context) ([]*entities.FundRecord, error)
+	FindFundOverviews(ctx context.Context) ([]*entities.FundOverviewRecord, error)
+	FindFundHoldings(ctx context.Context) ([]*entities.FundHoldingRecord, error)
+	FindFundDistributions(ctx context.Context) ([]*entities.FundDistributionRecord, error)
+}
+
+// Writer interface
+type Writer interface{}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"context"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+// Dataset struct holds every stored document needed by the exporters
+type Dataset struct {
+	Funds         []*entities.FundRecord
+	Overviews     []*entities.FundOverviewRecord
+	Holdings      []*entities.FundHoldingRecord
+	Distributions []*entities.FundDistributionRecord
+}
+
+// AssetCodes maps each fund ticker to its asset code
+func (d *Dataset) AssetCodes() map[string]string {
+	assetCodes := make(map[string]string)
+
+	for _, fund := range d.Funds {
+		assetCodes[fund.Ticker] = fund.AssetCode
+	}
+
+	return assetCodes
+}
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetDataset gets all stored funds, overviews, holdings and distributions
+func (s *Service) GetDataset(ctx context.Context) (*Dataset, error) {
+	s.log.Info(ctx, "get export dataset")
+
+	funds, err := s.repo.FindFunds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overviews, err := s.repo.FindFundOverviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := s.repo.FindFundHoldings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	distributions, err := s.repo.FindFundDistributions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dataset{
+		Funds:         funds,
+		Overviews:     overviews,
+		Holdings:      holdings,
+		Distributions: distributions,
+	}, nil
+}
@@ -16,6 +16,8 @@ type Reader interface{}
 // Writer interface
 type Writer interface {
 	InsertFund(ctx context.Context, fund *entities.Fund) error
+	ReconcileFundList(ctx context.Context, tickers []string) error
+	InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error
 }
 
 // Repo interface
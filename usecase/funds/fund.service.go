@@ -5,24 +5,39 @@ import (
 
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/validation"
 )
 
 // Service sector
 type Service struct {
-	repo Repo
-	log  logger.ContextLog
+	repo              Repo
+	validationService *validation.Service
+	log               logger.ContextLog
 }
 
 // NewService create new service
-func NewService(repo Repo, log logger.ContextLog) *Service {
+func NewService(repo Repo, validationService *validation.Service, log logger.ContextLog) *Service {
 	return &Service{
-		repo: repo,
-		log:  log,
+		repo:              repo,
+		validationService: validationService,
+		log:               log,
 	}
 }
 
 // CreateFund creates new fund
 func (s *Service) CreateFund(ctx context.Context, fund *entities.Fund) error {
 	s.log.Info(ctx, "creating new fund")
+
+	result := s.validationService.ValidateFund(ctx, fund)
+	if err := validation.Enforce(ctx, s.repo, result, &entities.QuarantinedItem{Fund: fund}); err != nil {
+		return err
+	}
+
 	return s.repo.InsertFund(ctx, fund)
 }
+
+// ReconcileFundList reconciles stored funds with the tickers of the scraped fund list
+func (s *Service) ReconcileFundList(ctx context.Context, tickers []string) error {
+	s.log.Info(ctx, "reconcile fund list", "tickers", len(tickers))
+	return s.repo.ReconcileFundList(ctx, tickers)
+}
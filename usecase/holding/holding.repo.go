@@ -16,6 +16,7 @@ type Reader interface{}
 // Writer interface
 type Writer interface {
 	InsertFundHolding(ctx context.Context, fundHolding *entities.FundHolding) error
+	InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error
 }
 
 // Repo interface
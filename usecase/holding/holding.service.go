@@ -5,24 +5,33 @@ import (
 
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/validation"
 )
 
 // Service sector
 type Service struct {
-	repo Repo
-	log  logger.ContextLog
+	repo              Repo
+	validationService *validation.Service
+	log               logger.ContextLog
 }
 
 // NewService create new service
-func NewService(repo Repo, log logger.ContextLog) *Service {
+func NewService(repo Repo, validationService *validation.Service, log logger.ContextLog) *Service {
 	return &Service{
-		repo: repo,
-		log:  log,
+		repo:              repo,
+		validationService: validationService,
+		log:               log,
 	}
 }
 
 // CreateFundHolding creates new fund holding
 func (s *Service) CreateFundHolding(ctx context.Context, fundHolding *entities.FundHolding) error {
 	s.log.Info(ctx, "create new fund holding")
+
+	result := s.validationService.ValidateFundHolding(ctx, fundHolding)
+	if err := validation.Enforce(ctx, s.repo, result, &entities.QuarantinedItem{Holding: fundHolding}); err != nil {
+		return err
+	}
+
 	return s.repo.InsertFundHolding(ctx, fundHolding)
 }
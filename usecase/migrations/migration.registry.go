@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MigrateFunc changes the shape of a raw document in place
+type MigrateFunc func(doc bson.M) error
+
+// Migration struct moves the documents of a collection between a schema version and the previous one.
+// Up upgrades documents stamped with Version-1 to Version, Down reverts them.
+type Migration struct {
+	Collection  string
+	Version     int
+	Description string
+	Up          MigrateFunc
+	Down        MigrateFunc
+}
+
+// registry holds registered migrations keyed by collection and version
+var registry = make(map[string]map[int]*Migration)
+
+// lookupIndexes holds the fields indexed for lookups keyed by collection
+var lookupIndexes = make(map[string][]string)
+
+// Register registers a migration, it is meant to be called from init functions of migration files.
+// Registering two migrations of the same collection and version is a programming error.
+func Register(migration *Migration) {
+	if migration.Version < 2 {
+		panic(fmt.Sprintf("migration %s of %s: version must be greater than 1", migration.Description, migration.Collection))
+	}
+
+	if migration.Up == nil || migration.Down == nil {
+		panic(fmt.Sprintf("migration %s of %s: both up and down are required", migration.Description, migration.Collection))
+	}
+
+	versions, ok := registry[migration.Collection]
+	if !ok {
+		versions = make(map[int]*Migration)
+		registry[migration.Collection] = versions
+	}
+
+	if _, ok := versions[migration.Version]; ok {
+		panic(fmt.Sprintf("migration of %s to version %d is already registered", migration.Collection, migration.Version))
+	}
+
+	versions[migration.Version] = migration
+}
+
+// RegisterLookupIndex registers fields of a collection a repository looks documents up by, EnsureSchema indexes them.
+// It is meant to be called from init functions of the migration files which add the fields.
+func RegisterLookupIndex(collection string, keys ...string) {
+	lookupIndexes[collection] = append(lookupIndexes[collection], keys...)
+}
+
+// getMigration gets the registered migration of a collection to a version, nil if the version does not change its shape
+func getMigration(collection string, version int) *Migration {
+	return registry[collection][version]
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+///////////////////////////////////////////////////////////
+// Migration Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindSchemaVersions(ctx context.Context, collection string) ([]string, error)
+	FindDocumentsBySchema(ctx context.Context, collection string, version string) ([]bson.M, error)
+	FindMigrations(ctx context.Context) ([]*entities.MigrationResult, error)
+}
+
+// Writer interface
+type Writer interface {
+	ReplaceDocument(ctx context.Context, collection string, doc bson.M) error
+	EnsureUniqueIndex(ctx context.Context, collection string, key string) error
+	EnsureIndex(ctx context.Context, collection string, key string) error
+	InsertMigration(ctx context.Context, migration *entities.MigrationResult) error
+}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
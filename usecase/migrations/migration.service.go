@@ -0,0 +1,261 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalidVersion is returned when the target schema version is not a positive number
+var ErrInvalidVersion = errors.New("schema version must be a positive number")
+
+// ErrSchemaAhead is returned when stored documents are stamped with a newer schema version than the running build
+var ErrSchemaAhead = errors.New("stored documents are ahead of the schema version")
+
+// MigratableCollections are the collections whose documents are stamped with a schema version
+var MigratableCollections = []string{
+	consts.VANGUARD_FUND_LIST_COLLECTION,
+	consts.VANGUARD_FUND_OVERVIEW_COLLECTION,
+	consts.VANGUARD_FUND_HOLDING_COLLECTION,
+	consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION,
+	consts.VANGUARD_FUND_PERFORMANCE_COLLECTION,
+	consts.VANGUARD_FUND_CHANGE_COLLECTION,
+}
+
+// KeyedCollections are the collections that hold one document per fund ticker
+var KeyedCollections = []string{
+	consts.VANGUARD_FUND_LIST_COLLECTION,
+	consts.VANGUARD_FUND_OVERVIEW_COLLECTION,
+	consts.VANGUARD_FUND_HOLDING_COLLECTION,
+	consts.VANGUARD_FUND_DISTRIBUTION_COLLECTION,
+	consts.VANGUARD_FUND_PERFORMANCE_COLLECTION,
+}
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// Migrate upgrades or downgrades the documents of every collection one version at a time until they reach the target schema version.
+// A version without a registered migration for a collection only restamps its documents.
+// In dry-run mode documents are migrated in memory only, nothing is written, and each version step carries the
+// documents migrated by the previous step forward so it counts what a real run would migrate.
+func (s *Service) Migrate(ctx context.Context, target string, dryRun bool) ([]*entities.MigrationResult, error) {
+	s.log.Info(ctx, "migrate schema", "target", target, "dryRun", dryRun)
+
+	targetVersion, err := strconv.Atoi(target)
+	if err != nil || targetVersion < 1 {
+		return nil, ErrInvalidVersion
+	}
+
+	var results []*entities.MigrationResult
+	for _, collection := range MigratableCollections {
+		rawVersions, err := s.repo.FindSchemaVersions(ctx, collection)
+		if err != nil {
+			return results, err
+		}
+
+		var lowest, highest int
+		for _, rawVersion := range rawVersions {
+			version, err := strconv.Atoi(rawVersion)
+			if err != nil {
+				s.log.Warn(ctx, "skip documents with invalid schema version", "collection", collection, "schema", rawVersion)
+				continue
+			}
+
+			if lowest == 0 || version < lowest {
+				lowest = version
+			}
+
+			if version > highest {
+				highest = version
+			}
+		}
+
+		if lowest == 0 {
+			continue
+		}
+
+		var carried []bson.M
+		for version := lowest + 1; version <= targetVersion; version++ {
+			result, migrated, err := s.migrate(ctx, collection, version-1, version, dryRun, carried)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, result)
+			carried = migrated
+		}
+
+		carried = nil
+		for version := highest; version > targetVersion; version-- {
+			result, migrated, err := s.migrate(ctx, collection, version, version-1, dryRun, carried)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, result)
+			carried = migrated
+		}
+	}
+
+	return results, nil
+}
+
+// EnsureSchema upgrades the stored documents to the target schema version before a scrape writes any,
+// so a migration that rewrites the ticker key never races a scrape that already writes the new key.
+// It never downgrades, documents ahead of the target fail with ErrSchemaAhead instead.
+// The ticker of every keyed collection is then indexed as unique, a duplicate left by an earlier scrape fails here loudly,
+// and the registered lookup fields are indexed.
+func (s *Service) EnsureSchema(ctx context.Context, target string) error {
+	s.log.Info(ctx, "ensure schema", "target", target)
+
+	targetVersion, err := strconv.Atoi(target)
+	if err != nil || targetVersion < 1 {
+		return ErrInvalidVersion
+	}
+
+	for _, collection := range MigratableCollections {
+		rawVersions, err := s.repo.FindSchemaVersions(ctx, collection)
+		if err != nil {
+			return err
+		}
+
+		for _, rawVersion := range rawVersions {
+			if version, err := strconv.Atoi(rawVersion); err == nil && version > targetVersion {
+				return fmt.Errorf("%w: %s has documents at version %d, target is %d", ErrSchemaAhead, collection, version, targetVersion)
+			}
+		}
+	}
+
+	results, err := s.Migrate(ctx, target, false)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		s.log.Info(ctx, "schema migrated", "collection", result.Collection, "from", result.FromVersion, "to", result.ToVersion, "documents", result.Documents)
+	}
+
+	for _, collection := range KeyedCollections {
+		if err := s.repo.EnsureUniqueIndex(ctx, collection, "ticker"); err != nil {
+			return err
+		}
+	}
+
+	for _, collection := range MigratableCollections {
+		for _, key := range lookupIndexes[collection] {
+			if err := s.repo.EnsureIndex(ctx, collection, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetAppliedMigrations gets the migrations-applied ledger, oldest first
+func (s *Service) GetAppliedMigrations(ctx context.Context) ([]*entities.MigrationResult, error) {
+	s.log.Info(ctx, "get applied migrations")
+
+	return s.repo.FindMigrations(ctx)
+}
+
+// migrate moves the documents of a collection from a schema version to an adjacent one. A dry run also migrates the
+// documents carried from the previous step, which are not stored at the from version, and returns them all for the next.
+func (s *Service) migrate(ctx context.Context, collection string, from, to int, dryRun bool, carried []bson.M) (*entities.MigrationResult, []bson.M, error) {
+	result := &entities.MigrationResult{
+		Collection:  collection,
+		Direction:   entities.MIGRATION_DIRECTION_UP,
+		FromVersion: strconv.Itoa(from),
+		ToVersion:   strconv.Itoa(to),
+		DryRun:      dryRun,
+	}
+
+	// an upgrade runs the up function of the target version, a downgrade runs the down function of the current version
+	var migrate MigrateFunc
+	if to > from {
+		if migration := getMigration(collection, to); migration != nil {
+			result.Description = migration.Description
+			migrate = migration.Up
+		}
+	} else {
+		result.Direction = entities.MIGRATION_DIRECTION_DOWN
+		if migration := getMigration(collection, from); migration != nil {
+			result.Description = migration.Description
+			migrate = migration.Down
+		}
+	}
+
+	docs, err := s.repo.FindDocumentsBySchema(ctx, collection, result.FromVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	docs = append(docs, carried...)
+
+	for _, doc := range docs {
+		if err := migrateDocument(doc, migrate, result.ToVersion); err != nil {
+			s.log.Error(ctx, "migrate document failed", "collection", collection, "id", doc["_id"], "from", result.FromVersion, "to", result.ToVersion, "error", err)
+			return nil, nil, err
+		}
+
+		if dryRun {
+			continue
+		}
+
+		if err := s.repo.ReplaceDocument(ctx, collection, doc); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	result.Documents = len(docs)
+	s.log.Info(ctx, "migrate documents", "collection", collection, "direction", result.Direction, "from", result.FromVersion, "to", result.ToVersion, "documents", result.Documents, "dryRun", dryRun)
+
+	if dryRun {
+		return result, docs, nil
+	}
+
+	if result.Documents == 0 {
+		return result, nil, nil
+	}
+
+	if err := s.repo.InsertMigration(ctx, result); err != nil {
+		return nil, nil, err
+	}
+
+	return result, nil, nil
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// migrateDocument applies a migration function if any and stamps the document with the new schema version
+func migrateDocument(doc bson.M, migrate MigrateFunc, version string) error {
+	if migrate != nil {
+		if err := migrate(doc); err != nil {
+			return err
+		}
+	}
+
+	doc["schema"] = version
+	if _, ok := doc["modifiedAt"]; ok {
+		doc["modifiedAt"] = time.Now().UTC().Unix()
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const testCollection = "test_collection"
+
+func init() {
+	RegisterLookupIndex(testCollection, "fundName")
+
+	Register(&Migration{
+		Collection:  testCollection,
+		Version:     2,
+		Description: "rename name to fundName",
+		Up: func(doc bson.M) error {
+			doc["fundName"] = doc["name"]
+			delete(doc, "name")
+			return nil
+		},
+		Down: func(doc bson.M) error {
+			doc["name"] = doc["fundName"]
+			delete(doc, "fundName")
+			return nil
+		},
+	})
+
+	Register(&Migration{
+		Collection:  testCollection,
+		Version:     3,
+		Description: "add currency",
+		Up: func(doc bson.M) error {
+			doc["currency"] = "CAD"
+			return nil
+		},
+		Down: func(doc bson.M) error {
+			delete(doc, "currency")
+			return nil
+		},
+	})
+}
+
+// memoryRepo is a migration repo keeping raw documents in memory
+type memoryRepo struct {
+	docs       map[string][]bson.M
+	migrations []*entities.MigrationResult
+	indexed    []string
+}
+
+func (r *memoryRepo) FindSchemaVersions(ctx context.Context, collection string) ([]string, error) {
+	seen := make(map[string]bool)
+	var versions []string
+	for _, doc := range r.docs[collection] {
+		if version, ok := doc["schema"].(string); ok && !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, nil
+}
+
+func (r *memoryRepo) FindDocumentsBySchema(ctx context.Context, collection string, version string) ([]bson.M, error) {
+	var docs []bson.M
+	for _, doc := range r.docs[collection] {
+		if doc["schema"] == version {
+			// a copy, like a document read from mongo
+			copied := make(bson.M)
+			for key, value := range doc {
+				copied[key] = value
+			}
+			docs = append(docs, copied)
+		}
+	}
+
+	return docs, nil
+}
+
+func (r *memoryRepo) FindMigrations(ctx context.Context) ([]*entities.MigrationResult, error) {
+	return r.migrations, nil
+}
+
+func (r *memoryRepo) ReplaceDocument(ctx context.Context, collection string, doc bson.M) error {
+	for i, stored := range r.docs[collection] {
+		if stored["_id"] == doc["_id"] {
+			r.docs[collection][i] = doc
+			return nil
+		}
+	}
+
+	return fmt.Errorf("document %v not found", doc["_id"])
+}
+
+func (r *memoryRepo) EnsureUniqueIndex(ctx context.Context, collection string, key string) error {
+	r.indexed = append(r.indexed, collection+"."+key)
+	return nil
+}
+
+func (r *memoryRepo) EnsureIndex(ctx context.Context, collection string, key string) error {
+	r.indexed = append(r.indexed, collection+"."+key)
+	return nil
+}
+
+func (r *memoryRepo) InsertMigration(ctx context.Context, migration *entities.MigrationResult) error {
+	r.migrations = append(r.migrations, migration)
+	return nil
+}
+
+// newTestService creates a service migrating only the test collection
+func newTestService(t *testing.T, docs ...bson.M) (*Service, *memoryRepo) {
+	collections, keyedCollections := MigratableCollections, KeyedCollections
+	MigratableCollections, KeyedCollections = []string{testCollection}, []string{testCollection}
+	t.Cleanup(func() {
+		MigratableCollections, KeyedCollections = collections, keyedCollections
+	})
+
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	repo := &memoryRepo{docs: map[string][]bson.M{testCollection: docs}}
+	return NewService(repo, log), repo
+}
+
+func TestRegister(t *testing.T) {
+	noop := func(doc bson.M) error { return nil }
+
+	tests := []struct {
+		name      string
+		migration *Migration
+	}{
+		{"version 1", &Migration{Collection: testCollection, Version: 1, Up: noop, Down: noop}},
+		{"without down", &Migration{Collection: testCollection, Version: 9, Up: noop}},
+		{"without up", &Migration{Collection: testCollection, Version: 9, Down: noop}},
+		{"registered twice", &Migration{Collection: testCollection, Version: 2, Up: noop, Down: noop}},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Register did not panic", test.name)
+				}
+			}()
+
+			Register(test.migration)
+		}()
+	}
+
+	if getMigration(testCollection, 2) == nil || getMigration(testCollection, 4) != nil {
+		t.Errorf("getMigration of %s = %v and %v, want version 2 only", testCollection, getMigration(testCollection, 2), getMigration(testCollection, 4))
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	original := bson.M{"_id": 1, "schema": "1", "name": "Vanguard S&P 500 Index ETF"}
+
+	tests := []struct {
+		name      string
+		target    string
+		dryRun    bool
+		want      bson.M
+		wantSteps []string
+		wantErr   error
+	}{
+		{"up", "3", false, bson.M{"_id": 1, "schema": "3", "fundName": "Vanguard S&P 500 Index ETF", "currency": "CAD"}, []string{"UP 1-2", "UP 2-3"}, nil},
+		{"restamp only", "4", false, bson.M{"_id": 1, "schema": "4", "fundName": "Vanguard S&P 500 Index ETF", "currency": "CAD"}, []string{"UP 1-2", "UP 2-3", "UP 3-4"}, nil},
+		{"dry run", "3", true, original, []string{"UP 1-2", "UP 2-3"}, nil},
+		{"current", "1", false, original, nil, nil},
+		{"invalid", "latest", false, original, nil, ErrInvalidVersion},
+		{"zero", "0", false, original, nil, ErrInvalidVersion},
+	}
+
+	for _, test := range tests {
+		doc := make(bson.M)
+		for key, value := range original {
+			doc[key] = value
+		}
+
+		service, repo := newTestService(t, doc)
+		results, err := service.Migrate(context.Background(), test.target, test.dryRun)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s: Migrate(%s) error = %v, want %v", test.name, test.target, err, test.wantErr)
+			continue
+		}
+
+		var steps []string
+		for _, result := range results {
+			steps = append(steps, fmt.Sprintf("%s %s-%s", result.Direction, result.FromVersion, result.ToVersion))
+		}
+
+		if !reflect.DeepEqual(steps, test.wantSteps) {
+			t.Errorf("%s: Migrate(%s) steps = %v, want %v", test.name, test.target, steps, test.wantSteps)
+		}
+
+		// every step moves the one document, a dry run too
+		for _, result := range results {
+			if result.Documents != 1 {
+				t.Errorf("%s: Migrate(%s) step %s-%s migrated %d documents, want 1", test.name, test.target, result.FromVersion, result.ToVersion, result.Documents)
+			}
+		}
+
+		if got := repo.docs[testCollection][0]; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Migrate(%s) document = %v, want %v", test.name, test.target, got, test.want)
+		}
+
+		// a dry run records nothing in the ledger
+		wantLedger := len(test.wantSteps)
+		if test.dryRun {
+			wantLedger = 0
+		}
+
+		if len(repo.migrations) != wantLedger {
+			t.Errorf("%s: Migrate(%s) recorded %d ledger entries, want %d", test.name, test.target, len(repo.migrations), wantLedger)
+		}
+	}
+}
+
+func TestMigrateDown(t *testing.T) {
+	service, repo := newTestService(t, bson.M{"_id": 1, "schema": "3", "fundName": "Vanguard S&P 500 Index ETF", "currency": "CAD"})
+
+	results, err := service.Migrate(context.Background(), "1", false)
+	if err != nil {
+		t.Fatalf("Migrate(1) failed: %v", err)
+	}
+
+	var steps []string
+	for _, result := range results {
+		steps = append(steps, fmt.Sprintf("%s %s-%s %s", result.Direction, result.FromVersion, result.ToVersion, result.Description))
+	}
+
+	wantSteps := []string{"DOWN 3-2 add currency", "DOWN 2-1 rename name to fundName"}
+	if !reflect.DeepEqual(steps, wantSteps) {
+		t.Errorf("Migrate(1) steps = %v, want %v", steps, wantSteps)
+	}
+
+	want := bson.M{"_id": 1, "schema": "1", "name": "Vanguard S&P 500 Index ETF"}
+	if got := repo.docs[testCollection][0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Migrate(1) document = %v, want %v", got, want)
+	}
+}
+
+func TestEnsureSchema(t *testing.T) {
+	service, repo := newTestService(t, bson.M{"_id": 1, "schema": "1", "name": "Vanguard FTSE Canada All Cap Index ETF"}, bson.M{"_id": 2, "schema": "3"})
+
+	if err := service.EnsureSchema(context.Background(), "2"); !errors.Is(err, ErrSchemaAhead) {
+		t.Errorf("EnsureSchema(2) error = %v, want ErrSchemaAhead", err)
+	}
+
+	if err := service.EnsureSchema(context.Background(), "3"); err != nil {
+		t.Fatalf("EnsureSchema(3) failed: %v", err)
+	}
+
+	if got := repo.docs[testCollection][0]["schema"]; got != "3" {
+		t.Errorf("EnsureSchema(3) left the first document at version %v", got)
+	}
+
+	if want := []string{testCollection + ".ticker", testCollection + ".fundName"}; !reflect.DeepEqual(repo.indexed, want) {
+		t.Errorf("indexed = %v, want %v", repo.indexed, want)
+	}
+}
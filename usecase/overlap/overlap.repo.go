@@ -0,0 +1,26 @@
+package overlap
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Overlap Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindFundOverviewsByTickers(ctx context.Context, tickers []string) ([]*entities.FundOverviewRecord, error)
+	FindFundHoldingsByTickers(ctx context.Context, tickers []string) ([]*entities.FundHoldingRecord, error)
+}
+
+// Writer interface
+type Writer interface{}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
@@ -0,0 +1,255 @@
+package overlap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// ErrInvalidFunds is returned when less than two funds are given
+var ErrInvalidFunds = errors.New("overlap needs at least two funds")
+
+// defaultTopOverlaps is used when the number of top overlapping names is not given
+const defaultTopOverlaps = 10
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// fundWeights struct holds weights of a fund keyed by symbol, sector code and country code
+type fundWeights struct {
+	ticker     string
+	securities map[string]decimal.Decimal
+	sectors    map[string]decimal.Decimal
+	countries  map[string]decimal.Decimal
+}
+
+// GetFundOverlap computes how much the given funds overlap. The overlap is the sum over
+// shared holdings of the smallest weight among the funds. Security level holdings are matched
+// by symbol, when one of the funds has no security level data the sector overlap is used instead.
+func (s *Service) GetFundOverlap(ctx context.Context, tickers []string, top int) (*entities.FundOverlap, error) {
+	s.log.Info(ctx, "get fund overlap", "tickers", tickers)
+
+	if top <= 0 {
+		top = defaultTopOverlaps
+	}
+
+	tickers = normalizeTickers(tickers)
+	if len(tickers) < 2 {
+		return nil, ErrInvalidFunds
+	}
+
+	overviews, err := s.repo.FindFundOverviewsByTickers(ctx, tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := s.repo.FindFundHoldingsByTickers(ctx, tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	weightsByTicker := make(map[string]*fundWeights)
+	getWeights := func(ticker string) *fundWeights {
+		if _, ok := weightsByTicker[ticker]; !ok {
+			weightsByTicker[ticker] = &fundWeights{
+				ticker:     ticker,
+				securities: make(map[string]decimal.Decimal),
+				sectors:    make(map[string]decimal.Decimal),
+				countries:  make(map[string]decimal.Decimal),
+			}
+		}
+		return weightsByTicker[ticker]
+	}
+
+	for _, overview := range overviews {
+		weights := getWeights(overview.Ticker)
+
+		for _, sector := range overview.Sectors {
+			weights.sectors[sector.SectorCode] = weights.sectors[sector.SectorCode].Add(sector.FundPercent)
+		}
+
+		for _, country := range overview.Countries {
+			weights.countries[country.CountryCode] = weights.countries[country.CountryCode].Add(country.FundMktPercent)
+		}
+	}
+
+	for _, holding := range holdings {
+		weights := getWeights(holding.Ticker)
+
+		for _, stock := range holding.Stocks {
+			symbol := strings.ToUpper(strings.TrimSpace(stock.Symbol))
+			if symbol == "" {
+				continue
+			}
+
+			weights.securities[symbol] = weights.securities[symbol].Add(stock.MarketValPercent)
+		}
+	}
+
+	var funds []*fundWeights
+	var missingTickers []string
+	for _, ticker := range tickers {
+		weights, ok := weightsByTicker[ticker]
+		if !ok {
+			s.log.Warn(ctx, "fund data not found", "ticker", ticker)
+			missingTickers = append(missingTickers, ticker)
+			continue
+		}
+
+		funds = append(funds, weights)
+	}
+
+	if len(funds) < 2 {
+		return nil, fmt.Errorf("%w: found data of %d fund(s) only", ErrInvalidFunds, len(funds))
+	}
+
+	overlap := computeOverlap(funds, top)
+	overlap.MissingTickers = missingTickers
+
+	if len(funds) > 2 {
+		for i := 0; i < len(funds); i++ {
+			for j := i + 1; j < len(funds); j++ {
+				overlap.Pairs = append(overlap.Pairs, computeOverlap([]*fundWeights{funds[i], funds[j]}, top))
+			}
+		}
+	}
+
+	return overlap, nil
+}
+
+// computeOverlap computes overlap of all given funds together
+func computeOverlap(funds []*fundWeights, top int) *entities.FundOverlap {
+	overlap := &entities.FundOverlap{}
+
+	hasSecurities := true
+	for _, fund := range funds {
+		overlap.Tickers = append(overlap.Tickers, fund.ticker)
+		hasSecurities = hasSecurities && len(fund.securities) > 0
+	}
+
+	sectorOverlap, _ := sumMinWeights(funds, func(f *fundWeights) map[string]decimal.Decimal { return f.sectors })
+	countryOverlap, _ := sumMinWeights(funds, func(f *fundWeights) map[string]decimal.Decimal { return f.countries })
+	overlap.SectorOverlap = sectorOverlap
+	overlap.CountryOverlap = countryOverlap
+
+	if !hasSecurities {
+		// fall back to sector level overlap, then country level overlap
+		if sectorOverlap.IsPositive() {
+			overlap.Level = entities.OVERLAP_LEVEL_SECTOR
+			overlap.OverlapPercent = sectorOverlap
+		} else {
+			overlap.Level = entities.OVERLAP_LEVEL_COUNTRY
+			overlap.OverlapPercent = countryOverlap
+		}
+
+		return overlap
+	}
+
+	securityOverlap, shared := sumMinWeights(funds, func(f *fundWeights) map[string]decimal.Decimal { return f.securities })
+	overlap.Level = entities.OVERLAP_LEVEL_SECURITY
+	overlap.OverlapPercent = securityOverlap
+	overlap.SharedCount = len(shared)
+
+	var sharedHoldings []*entities.SharedHolding
+	for _, symbol := range shared {
+		sharedHolding := &entities.SharedHolding{
+			Symbol:  symbol,
+			Weights: make(map[string]decimal.Decimal),
+		}
+
+		for i, fund := range funds {
+			weight := fund.securities[symbol]
+			sharedHolding.Weights[fund.ticker] = weight
+
+			if i == 0 {
+				sharedHolding.OverlapWeight = weight
+			} else {
+				sharedHolding.OverlapWeight = decimal.Min(sharedHolding.OverlapWeight, weight)
+			}
+		}
+
+		sharedHoldings = append(sharedHoldings, sharedHolding)
+	}
+
+	sort.Slice(sharedHoldings, func(i, j int) bool {
+		if !sharedHoldings[i].OverlapWeight.Equal(sharedHoldings[j].OverlapWeight) {
+			return sharedHoldings[i].OverlapWeight.GreaterThan(sharedHoldings[j].OverlapWeight)
+		}
+		return sharedHoldings[i].Symbol < sharedHoldings[j].Symbol
+	})
+
+	if len(sharedHoldings) > top {
+		sharedHoldings = sharedHoldings[:top]
+	}
+	overlap.TopOverlaps = sharedHoldings
+
+	return overlap
+}
+
+// sumMinWeights sums the smallest weight of every key held by all funds, it also returns the shared keys
+func sumMinWeights(funds []*fundWeights, weightsOf func(*fundWeights) map[string]decimal.Decimal) (decimal.Decimal, []string) {
+	total := decimal.Zero
+	var shared []string
+
+	for key, weight := range weightsOf(funds[0]) {
+		minWeight := weight
+
+		for _, fund := range funds[1:] {
+			other, ok := weightsOf(fund)[key]
+			if !ok {
+				minWeight = decimal.Zero
+				break
+			}
+
+			minWeight = decimal.Min(minWeight, other)
+		}
+
+		if minWeight.IsPositive() {
+			total = total.Add(minWeight)
+			shared = append(shared, key)
+		}
+	}
+
+	sort.Strings(shared)
+	return total, shared
+}
+
+// normalizeTickers converts tickers to yahoo tickers and removes duplicates
+func normalizeTickers(tickers []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+
+	for _, t := range tickers {
+		if strings.TrimSpace(t) == "" {
+			continue
+		}
+
+		yahooTicker := ticker.NormalizeYahooTicker(t)
+		if seen[yahooTicker] {
+			continue
+		}
+
+		seen[yahooTicker] = true
+		normalized = append(normalized, yahooTicker)
+	}
+
+	return normalized
+}
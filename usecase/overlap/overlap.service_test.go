@@ -0,0 +1,110 @@
+package overlap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+type fakeRepo struct {
+	overviews []*entities.FundOverviewRecord
+	holdings  []*entities.FundHoldingRecord
+}
+
+func (r *fakeRepo) FindFundOverviewsByTickers(ctx context.Context, tickers []string) ([]*entities.FundOverviewRecord, error) {
+	return r.overviews, nil
+}
+
+func (r *fakeRepo) FindFundHoldingsByTickers(ctx context.Context, tickers []string) ([]*entities.FundHoldingRecord, error) {
+	return r.holdings, nil
+}
+
+func stocks(ticker string, weights map[string]float64) *entities.FundHoldingRecord {
+	holding := &entities.FundHoldingRecord{Ticker: ticker}
+	for symbol, weight := range weights {
+		holding.Stocks = append(holding.Stocks, &entities.SectorWeightStockRecord{Symbol: symbol, MarketValPercent: decimal.NewFromFloat(weight)})
+	}
+	return holding
+}
+
+func newTestService(t *testing.T, repo *fakeRepo) *Service {
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+	return NewService(repo, log)
+}
+
+func TestGetFundOverlapSecurities(t *testing.T) {
+	service := newTestService(t, &fakeRepo{
+		overviews: []*entities.FundOverviewRecord{{Ticker: "VFV.TO"}, {Ticker: "VUN.TO"}, {Ticker: "VXC.TO"}},
+		holdings: []*entities.FundHoldingRecord{
+			stocks("VFV.TO", map[string]float64{"AAPL": 7, "MSFT": 6, "AMZN": 4}),
+			stocks("VUN.TO", map[string]float64{"AAPL": 5.5, "msft ": 5, "TSLA": 1}),
+			stocks("VXC.TO", map[string]float64{"AAPL": 3, "NESN": 1}),
+		},
+	})
+
+	overlap, err := service.GetFundOverlap(context.Background(), []string{"vfv", "VUN.TO", "VXC", "VFV.TO"}, 1)
+	if err != nil {
+		t.Fatalf("GetFundOverlap error = %v", err)
+	}
+
+	// only AAPL is held by all three, at 3% or more
+	if overlap.Level != entities.OVERLAP_LEVEL_SECURITY || !overlap.OverlapPercent.Equal(decimal.NewFromInt(3)) || overlap.SharedCount != 1 {
+		t.Errorf("overlap = %s %v%% of %d, want SECURITY 3%% of 1", overlap.Level, overlap.OverlapPercent, overlap.SharedCount)
+	}
+
+	if len(overlap.Pairs) != 3 {
+		t.Fatalf("got %d pairs, want 3", len(overlap.Pairs))
+	}
+
+	// VFV and VUN share AAPL 5.5 and MSFT 5, the top list is cut at one name
+	pair := overlap.Pairs[0]
+	if !pair.OverlapPercent.Equal(decimal.New(105, -1)) || pair.SharedCount != 2 || len(pair.TopOverlaps) != 1 || pair.TopOverlaps[0].Symbol != "AAPL" {
+		t.Errorf("VFV/VUN pair = %v%% of %d, top %v, want 10.5%% of 2, top AAPL", pair.OverlapPercent, pair.SharedCount, pair.TopOverlaps)
+	}
+
+	if got := pair.TopOverlaps[0].Weights["VUN.TO"]; !got.Equal(decimal.NewFromFloat(5.5)) {
+		t.Errorf("AAPL weight in VUN.TO = %v, want 5.5", got)
+	}
+}
+
+func TestGetFundOverlapFallback(t *testing.T) {
+	service := newTestService(t, &fakeRepo{
+		overviews: []*entities.FundOverviewRecord{
+			{
+				Ticker:    "VAB.TO",
+				Countries: []*entities.CountryBreakdownRecord{{CountryCode: "CA", FundMktPercent: decimal.NewFromInt(100)}},
+			},
+			{
+				Ticker:    "VCN.TO",
+				Sectors:   []*entities.SectorBreakdownRecord{{SectorCode: "FIN", FundPercent: decimal.NewFromInt(35)}},
+				Countries: []*entities.CountryBreakdownRecord{{CountryCode: "CA", FundMktPercent: decimal.RequireFromString("99.2")}},
+			},
+		},
+		holdings: []*entities.FundHoldingRecord{stocks("VCN.TO", map[string]float64{"RY": 6})},
+	})
+
+	overlap, err := service.GetFundOverlap(context.Background(), []string{"VAB", "VCN", "VGG"}, 0)
+	if err != nil {
+		t.Fatalf("GetFundOverlap error = %v", err)
+	}
+
+	// the bond fund has neither holdings nor sectors, so only countries are compared
+	if overlap.Level != entities.OVERLAP_LEVEL_COUNTRY || !overlap.OverlapPercent.Equal(decimal.RequireFromString("99.2")) {
+		t.Errorf("overlap = %s %v%%, want COUNTRY 99.2%%", overlap.Level, overlap.OverlapPercent)
+	}
+
+	if len(overlap.MissingTickers) != 1 || overlap.MissingTickers[0] != "VGG.TO" {
+		t.Errorf("missing tickers = %v, want [VGG.TO]", overlap.MissingTickers)
+	}
+
+	if _, err := service.GetFundOverlap(context.Background(), []string{"VAB", "vab.to"}, 0); !errors.Is(err, ErrInvalidFunds) {
+		t.Errorf("GetFundOverlap of one fund error = %v, want ErrInvalidFunds", err)
+	}
+}
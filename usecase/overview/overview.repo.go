@@ -16,6 +16,7 @@ type Reader interface{}
 // Writer interface
 type Writer interface {
 	InsertFundOverview(ctx context.Context, fundOverview *entities.FundOverview) error
+	InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error
 }
 
 // Repo interface
@@ -2,27 +2,111 @@ package overview
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	logger "github.com/lenoobz/aws-lambda-logger"
 	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/validation"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/lookup"
 )
 
 // Service sector
 type Service struct {
-	repo Repo
-	log  logger.ContextLog
+	repo              Repo
+	validationService *validation.Service
+	log               logger.ContextLog
+
+	// country names no country is resolved from, keyed by name
+	mu         sync.Mutex
+	unresolved map[string]*entities.UnresolvedCountry
 }
 
 // NewService create new service
-func NewService(repo Repo, log logger.ContextLog) *Service {
+func NewService(repo Repo, validationService *validation.Service, log logger.ContextLog) *Service {
 	return &Service{
-		repo: repo,
-		log:  log,
+		repo:              repo,
+		validationService: validationService,
+		log:               log,
+		unresolved:        make(map[string]*entities.UnresolvedCountry),
 	}
 }
 
 // CreateFundOverview creates new overview
 func (s *Service) CreateFundOverview(ctx context.Context, fundOverview *entities.FundOverview) error {
 	s.log.Info(ctx, "create new fund overview")
+
+	s.recordUnresolvedCountries(fundOverview)
+
+	result := s.validationService.ValidateFundOverview(ctx, fundOverview)
+	if err := validation.Enforce(ctx, s.repo, result, &entities.QuarantinedItem{Overview: fundOverview}); err != nil {
+		return err
+	}
+
 	return s.repo.InsertFundOverview(ctx, fundOverview)
 }
+
+// GetUnresolvedCountries gets the country names no country was resolved from so far sorted by weight, heaviest first
+func (s *Service) GetUnresolvedCountries() []*entities.UnresolvedCountry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var unresolved []*entities.UnresolvedCountry
+	for _, country := range s.unresolved {
+		unresolved = append(unresolved, &entities.UnresolvedCountry{
+			CountryName: country.CountryName,
+			Weight:      country.Weight,
+			Tickers:     append([]string{}, country.Tickers...),
+		})
+	}
+
+	sort.SliceStable(unresolved, func(i, j int) bool {
+		if !unresolved[i].Weight.Equal(unresolved[j].Weight) {
+			return unresolved[i].Weight.GreaterThan(unresolved[j].Weight)
+		}
+		return unresolved[i].CountryName < unresolved[j].CountryName
+	})
+
+	return unresolved
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// recordUnresolvedCountries records the country names of a fund overview no country is resolved from
+func (s *Service) recordUnresolvedCountries(fundOverview *entities.FundOverview) {
+	var exchangeTicker string
+	if fundOverview.FundCode != nil {
+		exchangeTicker = fundOverview.FundCode.ExchangeTicker
+	}
+
+	for _, countryBreakdown := range fundOverview.Countries {
+		if countryBreakdown == nil || countryBreakdown.CountryName == "" {
+			continue
+		}
+
+		if country, _ := lookup.ResolveCountry(countryBreakdown.CountryName); country != nil {
+			continue
+		}
+
+		// a country without market percent has no weight to report
+		weight, err := decimal.NewFromString(countryBreakdown.FundMktPercent)
+		if err != nil || weight.IsZero() {
+			continue
+		}
+
+		s.mu.Lock()
+		unresolved, ok := s.unresolved[countryBreakdown.CountryName]
+		if !ok {
+			unresolved = &entities.UnresolvedCountry{CountryName: countryBreakdown.CountryName}
+			s.unresolved[countryBreakdown.CountryName] = unresolved
+		}
+		unresolved.Weight = unresolved.Weight.Add(weight)
+		if exchangeTicker != "" {
+			unresolved.Tickers = append(unresolved.Tickers, exchangeTicker)
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,29 @@
+package performance
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Performance Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindFundPerformances(ctx context.Context) ([]*entities.FundPerformanceRecord, error)
+	FindFundPerformancesByTickers(ctx context.Context, tickers []string) ([]*entities.FundPerformanceRecord, error)
+}
+
+// Writer interface
+type Writer interface {
+	InsertFundPerformance(ctx context.Context, fundPerformance *entities.FundPerformance) error
+	InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error
+}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
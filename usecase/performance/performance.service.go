@@ -0,0 +1,81 @@
+package performance
+
+import (
+	"context"
+	"sort"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/usecase/validation"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// Service sector
+type Service struct {
+	repo              Repo
+	validationService *validation.Service
+	log               logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, validationService *validation.Service, log logger.ContextLog) *Service {
+	return &Service{
+		repo:              repo,
+		validationService: validationService,
+		log:               log,
+	}
+}
+
+// CreateFundPerformance creates new fund performance
+func (s *Service) CreateFundPerformance(ctx context.Context, fundPerformance *entities.FundPerformance) error {
+	s.log.Info(ctx, "create new fund performance")
+
+	result := s.validationService.ValidateFundPerformance(ctx, fundPerformance)
+	if err := validation.Enforce(ctx, s.repo, result, &entities.QuarantinedItem{Performance: fundPerformance}); err != nil {
+		return err
+	}
+
+	return s.repo.InsertFundPerformance(ctx, fundPerformance)
+}
+
+// GetFundReturns gets the stored trailing returns of funds, all funds if no ticker is given
+func (s *Service) GetFundReturns(ctx context.Context, tickers []string) ([]*entities.FundReturns, error) {
+	s.log.Info(ctx, "get fund returns", "tickers", tickers)
+
+	var err error
+	var performances []*entities.FundPerformanceRecord
+
+	if len(tickers) == 0 {
+		if performances, err = s.repo.FindFundPerformances(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		var yahooTickers []string
+		for _, t := range tickers {
+			yahooTickers = append(yahooTickers, ticker.NormalizeYahooTicker(t))
+		}
+
+		if performances, err = s.repo.FindFundPerformancesByTickers(ctx, yahooTickers); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []*entities.FundReturns
+	for _, performance := range performances {
+		results = append(results, &entities.FundReturns{
+			Ticker:        performance.Ticker,
+			AsOfDate:      performance.AsOfDate,
+			InceptionDate: performance.InceptionDate,
+			BenchmarkName: performance.BenchmarkName,
+			Nav:           performance.NavReturns,
+			MarketPrice:   performance.MarketPriceReturns,
+			Benchmark:     performance.BenchmarkReturns,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Ticker < results[j].Ticker
+	})
+
+	return results, nil
+}
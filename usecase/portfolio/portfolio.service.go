@@ -0,0 +1,207 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// ErrInvalidPortfolio is returned when positions of a portfolio are not valid
+var ErrInvalidPortfolio = errors.New("invalid portfolio")
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetPortfolioExposure looks through the funds of a portfolio and computes its combined exposure.
+// Position weights are relative, e.g. 40/30/30 and 0.4/0.3/0.3 give the same result.
+// All returned weights are percentages of the whole portfolio.
+func (s *Service) GetPortfolioExposure(ctx context.Context, positions []*entities.PortfolioPosition) (*entities.PortfolioExposure, error) {
+	s.log.Info(ctx, "get portfolio exposure", "positions", len(positions))
+
+	positions, err := normalizePositions(positions)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickers []string
+	for _, position := range positions {
+		tickers = append(tickers, position.Ticker)
+	}
+
+	overviews, err := s.repo.FindFundOverviewsByTickers(ctx, tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := s.repo.FindFundHoldingsByTickers(ctx, tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	overviewsByTicker := make(map[string]*entities.FundOverviewRecord)
+	for _, overview := range overviews {
+		overviewsByTicker[overview.Ticker] = overview
+	}
+
+	holdingsByTicker := make(map[string]*entities.FundHoldingRecord)
+	for _, holding := range holdings {
+		holdingsByTicker[holding.Ticker] = holding
+	}
+
+	exposure := &entities.PortfolioExposure{
+		Positions: positions,
+	}
+
+	sectors := newExposureAggregator()
+	countries := newExposureAggregator()
+	securities := newExposureAggregator()
+
+	for _, position := range positions {
+		overview, ok := overviewsByTicker[position.Ticker]
+		if !ok {
+			s.log.Warn(ctx, "fund overview not found", "ticker", position.Ticker)
+			exposure.MissingTickers = append(exposure.MissingTickers, position.Ticker)
+			continue
+		}
+
+		// weight of the fund as a fraction of the portfolio
+		weight := position.Weight.Div(decimal.NewFromInt(100))
+
+		exposure.AllocationStock = exposure.AllocationStock.Add(weight.Mul(overview.AllocationStock))
+		exposure.AllocationBond = exposure.AllocationBond.Add(weight.Mul(overview.AllocationBond))
+		exposure.AllocationCash = exposure.AllocationCash.Add(weight.Mul(overview.AllocationCash))
+		exposure.WeightedMer = exposure.WeightedMer.Add(weight.Mul(overview.MerFee))
+
+		for _, sector := range overview.Sectors {
+			sectors.add(sector.SectorCode, sector.SectorName, weight.Mul(sector.FundPercent))
+		}
+
+		for _, country := range overview.Countries {
+			countries.add(country.CountryCode, country.CountryName, weight.Mul(country.FundMktPercent))
+		}
+
+		if holding, ok := holdingsByTicker[position.Ticker]; ok {
+			for _, stock := range holding.Stocks {
+				securities.add(stock.Symbol, stock.Symbol, weight.Mul(stock.MarketValPercent))
+			}
+		}
+	}
+
+	exposure.Sectors = sectors.weights()
+	exposure.Countries = countries.weights()
+	exposure.Holdings = securities.weights()
+
+	return exposure, nil
+}
+
+// normalizePositions merges duplicated tickers and scales weights so they add up to 100
+func normalizePositions(positions []*entities.PortfolioPosition) ([]*entities.PortfolioPosition, error) {
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("%w: portfolio has no position", ErrInvalidPortfolio)
+	}
+
+	total := decimal.Zero
+	var normalized []*entities.PortfolioPosition
+	byTicker := make(map[string]*entities.PortfolioPosition)
+
+	for _, position := range positions {
+		if position == nil {
+			return nil, fmt.Errorf("%w: portfolio has an empty position", ErrInvalidPortfolio)
+		}
+
+		if position.Weight.IsNegative() {
+			return nil, fmt.Errorf("%w: position %s has negative weight %v", ErrInvalidPortfolio, position.Ticker, position.Weight)
+		}
+
+		yahooTicker := ticker.NormalizeYahooTicker(position.Ticker)
+		total = total.Add(position.Weight)
+
+		if existing, ok := byTicker[yahooTicker]; ok {
+			existing.Weight = existing.Weight.Add(position.Weight)
+			continue
+		}
+
+		byTicker[yahooTicker] = &entities.PortfolioPosition{
+			Ticker: yahooTicker,
+			Weight: position.Weight,
+		}
+		normalized = append(normalized, byTicker[yahooTicker])
+	}
+
+	if !total.IsPositive() {
+		return nil, fmt.Errorf("%w: portfolio total weight must be positive", ErrInvalidPortfolio)
+	}
+
+	for _, position := range normalized {
+		position.Weight = position.Weight.Percent(total)
+	}
+
+	return normalized, nil
+}
+
+///////////////////////////////////////////////////////////
+// Exposure aggregator
+///////////////////////////////////////////////////////////
+
+// exposureAggregator sums weights by code
+type exposureAggregator struct {
+	exposures map[string]*entities.ExposureWeight
+}
+
+func newExposureAggregator() *exposureAggregator {
+	return &exposureAggregator{
+		exposures: make(map[string]*entities.ExposureWeight),
+	}
+}
+
+func (a *exposureAggregator) add(code, name string, weight decimal.Decimal) {
+	key := code
+	if key == "" {
+		key = name
+	}
+
+	if exposure, ok := a.exposures[key]; ok {
+		exposure.Weight = exposure.Weight.Add(weight)
+		return
+	}
+
+	a.exposures[key] = &entities.ExposureWeight{
+		Code:   code,
+		Name:   name,
+		Weight: weight,
+	}
+}
+
+// weights returns exposures sorted by weight descending
+func (a *exposureAggregator) weights() []*entities.ExposureWeight {
+	var weights []*entities.ExposureWeight
+	for _, exposure := range a.exposures {
+		weights = append(weights, exposure)
+	}
+
+	sort.Slice(weights, func(i, j int) bool {
+		if !weights[i].Weight.Equal(weights[j].Weight) {
+			return weights[i].Weight.GreaterThan(weights[j].Weight)
+		}
+		return weights[i].Code < weights[j].Code
+	})
+
+	return weights
+}
@@ -0,0 +1,120 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// fakeRepo serves overviews and holdings of a fixed set of funds
+type fakeRepo struct {
+	overviews []*entities.FundOverviewRecord
+	holdings  []*entities.FundHoldingRecord
+}
+
+func (r *fakeRepo) FindFundOverviewsByTickers(ctx context.Context, tickers []string) ([]*entities.FundOverviewRecord, error) {
+	return r.overviews, nil
+}
+
+func (r *fakeRepo) FindFundHoldingsByTickers(ctx context.Context, tickers []string) ([]*entities.FundHoldingRecord, error) {
+	return r.holdings, nil
+}
+
+func TestGetPortfolioExposure(t *testing.T) {
+	repo := &fakeRepo{
+		overviews: []*entities.FundOverviewRecord{
+			{
+				Ticker:          "VFV.TO",
+				AllocationStock: dec("100"),
+				MerFee:          dec("0.09"),
+				Sectors:         []*entities.SectorBreakdownRecord{{SectorCode: "IT", SectorName: "Information Technology", FundPercent: dec("30")}},
+				Countries:       []*entities.CountryBreakdownRecord{{CountryCode: "US", CountryName: "United States", FundMktPercent: dec("100")}},
+			},
+			{
+				Ticker:          "VCN.TO",
+				AllocationStock: dec("90"),
+				AllocationCash:  dec("10"),
+				MerFee:          dec("0.05"),
+				Sectors:         []*entities.SectorBreakdownRecord{{SectorCode: "IT", SectorName: "Information Technology", FundPercent: dec("10")}},
+				Countries:       []*entities.CountryBreakdownRecord{{CountryCode: "CA", CountryName: "Canada", FundMktPercent: dec("100")}},
+			},
+		},
+		holdings: []*entities.FundHoldingRecord{
+			{Ticker: "VFV.TO", Stocks: []*entities.SectorWeightStockRecord{{Symbol: "AAPL", MarketValPercent: dec("7")}}},
+		},
+	}
+
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	// vfv is listed twice and vcn without its suffix, weights are relative
+	positions := []*entities.PortfolioPosition{
+		{Ticker: "vfv", Weight: dec("1")},
+		{Ticker: "VCN", Weight: dec("2")},
+		{Ticker: "VFV.TO", Weight: dec("1")},
+		{Ticker: "VXC", Weight: dec("4")},
+	}
+
+	exposure, err := NewService(repo, log).GetPortfolioExposure(context.Background(), positions)
+	if err != nil {
+		t.Fatalf("GetPortfolioExposure error = %v", err)
+	}
+
+	if len(exposure.Positions) != 3 || exposure.Positions[0].Ticker != "VFV.TO" || !exposure.Positions[0].Weight.Equal(dec("25")) {
+		t.Fatalf("positions = %+v, want VFV.TO merged at 25%%", exposure.Positions)
+	}
+
+	if len(exposure.MissingTickers) != 1 || exposure.MissingTickers[0] != "VXC.TO" {
+		t.Errorf("missing tickers = %v, want [VXC.TO]", exposure.MissingTickers)
+	}
+
+	// 0.25 * 100 + 0.25 * 90
+	if !exposure.AllocationStock.Equal(dec("47.5")) || !exposure.AllocationCash.Equal(dec("2.5")) {
+		t.Errorf("allocation stock/cash = %v/%v, want 47.5/2.5", exposure.AllocationStock, exposure.AllocationCash)
+	}
+
+	if !exposure.WeightedMer.Equal(dec("0.035")) {
+		t.Errorf("weighted mer = %v, want 0.035", exposure.WeightedMer)
+	}
+
+	if len(exposure.Sectors) != 1 || !exposure.Sectors[0].Weight.Equal(dec("10")) {
+		t.Errorf("sectors = %+v, want IT at 10%%", exposure.Sectors)
+	}
+
+	if len(exposure.Countries) != 2 || exposure.Countries[0].Code != "CA" || exposure.Countries[1].Code != "US" {
+		t.Errorf("countries = %+v, want CA then US at 25%% each", exposure.Countries)
+	}
+
+	if len(exposure.Holdings) != 1 || !exposure.Holdings[0].Weight.Equal(dec("1.75")) {
+		t.Errorf("holdings = %+v, want AAPL at 1.75%%", exposure.Holdings)
+	}
+}
+
+func TestGetPortfolioExposureInvalid(t *testing.T) {
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+	service := NewService(&fakeRepo{}, log)
+
+	for _, positions := range [][]*entities.PortfolioPosition{
+		nil,
+		{{Ticker: "VFV", Weight: decimal.Zero}},
+		{{Ticker: "VFV", Weight: dec("50")}, {Ticker: "VCN", Weight: dec("-10")}},
+		{{Ticker: "VFV", Weight: dec("100")}, nil},
+	} {
+		if _, err := service.GetPortfolioExposure(context.Background(), positions); !errors.Is(err, ErrInvalidPortfolio) {
+			t.Errorf("GetPortfolioExposure(%v) error = %v, want ErrInvalidPortfolio", positions, err)
+		}
+	}
+}
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
@@ -0,0 +1,34 @@
+package quarantine
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Quarantine Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindQuarantinedItems(ctx context.Context, status string) ([]*entities.QuarantinedItem, error)
+	FindQuarantinedItemByID(ctx context.Context, id string) (*entities.QuarantinedItem, error)
+	FindLiveModifiedAt(ctx context.Context, kind string, ticker string) (int64, error)
+}
+
+// Writer interface
+type Writer interface {
+	UpdateQuarantinedItemStatus(ctx context.Context, id string, from string, to string) (bool, error)
+	InsertFund(ctx context.Context, fund *entities.Fund) error
+	InsertFundOverview(ctx context.Context, fundOverview *entities.FundOverview) error
+	InsertFundHolding(ctx context.Context, fundHolding *entities.FundHolding) error
+	InsertFundDistribution(ctx context.Context, fundDistribution *entities.FundDistribution) error
+	InsertFundPerformance(ctx context.Context, fundPerformance *entities.FundPerformance) error
+}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
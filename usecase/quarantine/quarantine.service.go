@@ -0,0 +1,162 @@
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+// ErrNotFound is returned when there is no quarantined item with the id
+var ErrNotFound = errors.New("quarantined item not found")
+
+// ErrAlreadyResolved is returned when approving or discarding an item which is no longer pending
+var ErrAlreadyResolved = errors.New("quarantined item already resolved")
+
+// ErrStale is returned when approving an item whose live document was stored after the item was quarantined
+var ErrStale = errors.New("live document is newer than the quarantined item")
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// ListQuarantinedItems lists quarantined items with a status without their raw entity, an empty status lists every item
+func (s *Service) ListQuarantinedItems(ctx context.Context, status string) ([]*entities.QuarantinedItem, error) {
+	s.log.Info(ctx, "list quarantined items", "status", status)
+
+	return s.repo.FindQuarantinedItems(ctx, status)
+}
+
+// GetQuarantinedItem gets a quarantined item with its raw entity
+func (s *Service) GetQuarantinedItem(ctx context.Context, id string) (*entities.QuarantinedItem, error) {
+	s.log.Info(ctx, "get quarantined item", "id", id)
+
+	item, err := s.repo.FindQuarantinedItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return nil, ErrNotFound
+	}
+
+	return item, nil
+}
+
+// ApproveQuarantinedItem promotes the raw entity of a pending item to the live collection, skipping validation.
+// An item older than its live document is refused unless forced, it would overwrite newer data.
+// The item is claimed as approved before it is promoted, so a concurrent approve or discard cannot promote it too,
+// and it is pending again if the promotion fails.
+func (s *Service) ApproveQuarantinedItem(ctx context.Context, id string, force bool) (*entities.QuarantinedItem, error) {
+	s.log.Info(ctx, "approve quarantined item", "id", id, "force", force)
+
+	item, err := s.getPendingItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !force {
+		modifiedAt, err := s.repo.FindLiveModifiedAt(ctx, item.Kind, item.Ticker)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.QuarantinedAt != nil && modifiedAt > item.QuarantinedAt.Unix() {
+			s.log.Warn(ctx, "live document is newer than the quarantined item", "id", id, "kind", item.Kind, "ticker", item.Ticker, "modifiedAt", modifiedAt)
+			return nil, ErrStale
+		}
+	}
+
+	if err := s.resolve(ctx, id, entities.QUARANTINE_STATUS_PENDING, entities.QUARANTINE_STATUS_APPROVED); err != nil {
+		return nil, err
+	}
+
+	switch item.Kind {
+	case entities.VALIDATION_KIND_FUND:
+		err = s.repo.InsertFund(ctx, item.Fund)
+	case entities.VALIDATION_KIND_OVERVIEW:
+		err = s.repo.InsertFundOverview(ctx, item.Overview)
+	case entities.VALIDATION_KIND_HOLDING:
+		err = s.repo.InsertFundHolding(ctx, item.Holding)
+	case entities.VALIDATION_KIND_DISTRIBUTION:
+		err = s.repo.InsertFundDistribution(ctx, item.Distribution)
+	case entities.VALIDATION_KIND_PERFORMANCE:
+		err = s.repo.InsertFundPerformance(ctx, item.Performance)
+	default:
+		err = fmt.Errorf("unsupported quarantine kind %q", item.Kind)
+	}
+
+	if err != nil {
+		s.log.Error(ctx, "promote quarantined item failed", "id", id, "kind", item.Kind, "error", err)
+
+		if rollbackErr := s.resolve(ctx, id, entities.QUARANTINE_STATUS_APPROVED, entities.QUARANTINE_STATUS_PENDING); rollbackErr != nil {
+			s.log.Error(ctx, "roll back quarantined item status failed", "id", id, "error", rollbackErr)
+		}
+
+		return nil, err
+	}
+
+	item.Status = entities.QUARANTINE_STATUS_APPROVED
+	return item, nil
+}
+
+// DiscardQuarantinedItem discards a pending item, the live collection keeps the stored document
+func (s *Service) DiscardQuarantinedItem(ctx context.Context, id string) (*entities.QuarantinedItem, error) {
+	s.log.Info(ctx, "discard quarantined item", "id", id)
+
+	item, err := s.getPendingItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resolve(ctx, id, entities.QUARANTINE_STATUS_PENDING, entities.QUARANTINE_STATUS_DISCARDED); err != nil {
+		return nil, err
+	}
+
+	item.Status = entities.QUARANTINE_STATUS_DISCARDED
+	return item, nil
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// getPendingItem gets a quarantined item which is neither approved nor discarded yet
+func (s *Service) getPendingItem(ctx context.Context, id string) (*entities.QuarantinedItem, error) {
+	item, err := s.GetQuarantinedItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status != entities.QUARANTINE_STATUS_PENDING {
+		return nil, ErrAlreadyResolved
+	}
+
+	return item, nil
+}
+
+// resolve moves an item from a status to another, ErrAlreadyResolved if another call moved it first
+func (s *Service) resolve(ctx context.Context, id string, from, to string) error {
+	ok, err := s.repo.UpdateQuarantinedItemStatus(ctx, id, from, to)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrAlreadyResolved
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+// memoryRepo keeps quarantined items, the promoted overviews with the status of their item when promoted and the
+// modified time of live documents by ticker in memory
+type memoryRepo struct {
+	items      map[string]*entities.QuarantinedItem
+	overviews  []*entities.FundOverview
+	promotedAs []string
+	modifiedAt map[string]int64
+}
+
+func (r *memoryRepo) FindQuarantinedItems(ctx context.Context, status string) ([]*entities.QuarantinedItem, error) {
+	var items []*entities.QuarantinedItem
+	for _, item := range r.items {
+		if status == "" || item.Status == status {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *memoryRepo) FindQuarantinedItemByID(ctx context.Context, id string) (*entities.QuarantinedItem, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *item
+	return &copied, nil
+}
+
+func (r *memoryRepo) FindLiveModifiedAt(ctx context.Context, kind string, ticker string) (int64, error) {
+	return r.modifiedAt[ticker], nil
+}
+
+func (r *memoryRepo) UpdateQuarantinedItemStatus(ctx context.Context, id string, from string, to string) (bool, error) {
+	item, ok := r.items[id]
+	if !ok || item.Status != from {
+		return false, nil
+	}
+
+	item.Status = to
+	return true, nil
+}
+
+func (r *memoryRepo) InsertFund(ctx context.Context, fund *entities.Fund) error {
+	return nil
+}
+
+func (r *memoryRepo) InsertFundOverview(ctx context.Context, fundOverview *entities.FundOverview) error {
+	r.overviews = append(r.overviews, fundOverview)
+	for _, item := range r.items {
+		if item.Overview == fundOverview {
+			r.promotedAs = append(r.promotedAs, item.Status)
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepo) InsertFundHolding(ctx context.Context, fundHolding *entities.FundHolding) error {
+	return nil
+}
+
+func (r *memoryRepo) InsertFundDistribution(ctx context.Context, fundDistribution *entities.FundDistribution) error {
+	return nil
+}
+
+func (r *memoryRepo) InsertFundPerformance(ctx context.Context, fundPerformance *entities.FundPerformance) error {
+	return nil
+}
+
+func TestApproveAndDiscard(t *testing.T) {
+	quarantinedAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	overview := &entities.FundOverview{PortID: "9563"}
+	repo := &memoryRepo{
+		items: map[string]*entities.QuarantinedItem{
+			"a": {ID: "a", Kind: entities.VALIDATION_KIND_OVERVIEW, Ticker: "VFV", Status: entities.QUARANTINE_STATUS_PENDING, QuarantinedAt: &quarantinedAt, Overview: overview},
+			"b": {ID: "b", Kind: entities.VALIDATION_KIND_OVERVIEW, Status: entities.QUARANTINE_STATUS_PENDING, Overview: &entities.FundOverview{PortID: "9555"}},
+			"c": {ID: "c", Kind: "PRICE_HISTORY", Status: entities.QUARANTINE_STATUS_PENDING},
+		},
+		modifiedAt: map[string]int64{"VFV": quarantinedAt.Add(time.Hour).Unix()},
+	}
+
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+	service := NewService(repo, log)
+	ctx := context.Background()
+
+	// the live overview was stored after the item was quarantined
+	if _, err := service.ApproveQuarantinedItem(ctx, "a", false); !errors.Is(err, ErrStale) || len(repo.overviews) != 0 {
+		t.Fatalf("ApproveQuarantinedItem(a) error = %v, want ErrStale", err)
+	}
+
+	item, err := service.ApproveQuarantinedItem(ctx, "a", true)
+	if err != nil || item.Status != entities.QUARANTINE_STATUS_APPROVED {
+		t.Fatalf("ApproveQuarantinedItem(a) = %v, %v", item, err)
+	}
+
+	if len(repo.overviews) != 1 || repo.overviews[0] != overview {
+		t.Errorf("promoted overviews = %v, want the quarantined one", repo.overviews)
+	}
+
+	// the item is claimed before its overview is promoted
+	if len(repo.promotedAs) != 1 || repo.promotedAs[0] != entities.QUARANTINE_STATUS_APPROVED {
+		t.Errorf("item status when promoted = %v, want APPROVED", repo.promotedAs)
+	}
+
+	if _, err := service.DiscardQuarantinedItem(ctx, "a"); !errors.Is(err, ErrAlreadyResolved) {
+		t.Errorf("DiscardQuarantinedItem of an approved item error = %v, want ErrAlreadyResolved", err)
+	}
+
+	if item, err := service.DiscardQuarantinedItem(ctx, "b"); err != nil || item.Status != entities.QUARANTINE_STATUS_DISCARDED || len(repo.overviews) != 1 {
+		t.Errorf("DiscardQuarantinedItem(b) = %v, %v and %d promoted overviews", item, err, len(repo.overviews))
+	}
+
+	// an unsupported kind is claimed, fails to promote and is pending again
+	if _, err := service.ApproveQuarantinedItem(ctx, "c", false); err == nil || repo.items["c"].Status != entities.QUARANTINE_STATUS_PENDING {
+		t.Errorf("ApproveQuarantinedItem(c) error = %v, status %s", err, repo.items["c"].Status)
+	}
+
+	if _, err := service.GetQuarantinedItem(ctx, "z"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetQuarantinedItem(z) error = %v, want ErrNotFound", err)
+	}
+
+	if pending, _ := service.ListQuarantinedItems(ctx, entities.QUARANTINE_STATUS_PENDING); len(pending) != 1 || pending[0].ID != "c" {
+		t.Errorf("pending items = %v, want c only", pending)
+	}
+}
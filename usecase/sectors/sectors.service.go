@@ -0,0 +1,132 @@
+package sectors
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/taxonomy"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// ErrUnknownTaxonomy is returned when the requested taxonomy version does not exist
+var ErrUnknownTaxonomy = errors.New("unknown sector taxonomy")
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetFundSectors gets the sector breakdown of the given funds remapped to a taxonomy version, the current one if empty,
+// all funds if no ticker is given
+func (s *Service) GetFundSectors(ctx context.Context, tickers []string, version string) ([]*entities.FundSectors, error) {
+	s.log.Info(ctx, "get fund sectors", "tickers", tickers, "version", version)
+
+	if version == "" {
+		version = taxonomy.CurrentVersion()
+	}
+
+	if taxonomy.GetVersion(version) == nil {
+		return nil, ErrUnknownTaxonomy
+	}
+
+	var err error
+	var overviews []*entities.FundOverviewRecord
+
+	if len(tickers) == 0 {
+		overviews, err = s.repo.FindFundOverviews(ctx)
+	} else {
+		var yahooTickers []string
+		for _, t := range tickers {
+			yahooTickers = append(yahooTickers, ticker.NormalizeYahooTicker(t))
+		}
+
+		overviews, err = s.repo.FindFundOverviewsByTickers(ctx, yahooTickers)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*entities.FundSectors
+	for _, overview := range overviews {
+		results = append(results, s.remapSectors(ctx, overview, version))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Ticker < results[j].Ticker
+	})
+
+	return results, nil
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// remapSectors remaps the sector breakdown of an overview to a taxonomy version and sums it by sector and industry group
+func (s *Service) remapSectors(ctx context.Context, overview *entities.FundOverviewRecord, version string) *entities.FundSectors {
+	fundSectors := &entities.FundSectors{
+		Ticker:   overview.Ticker,
+		Taxonomy: version,
+	}
+
+	// an overview stored before the taxonomy was versioned is classified under the current one
+	sectorTaxonomy := overview.SectorTaxonomy
+	if sectorTaxonomy == "" {
+		sectorTaxonomy = taxonomy.CurrentVersion()
+	}
+
+	weights := make(map[string]*entities.SectorWeight)
+	for _, sector := range overview.Sectors {
+		if sector == nil {
+			continue
+		}
+
+		weight := &entities.SectorWeight{
+			SectorCode: sector.SectorCode,
+			SectorName: sector.SectorName,
+		}
+
+		if classification := taxonomy.Find(sectorTaxonomy, sector.SectorCode, sector.IndustryGroupCode); classification != nil {
+			remapped, err := taxonomy.Remap(classification, version)
+			if err != nil {
+				s.log.Warn(ctx, "remap sector failed", "error", err, "ticker", overview.Ticker, "SectorCode", sector.SectorCode)
+			} else {
+				weight.SectorCode = remapped.Sector.Code
+				weight.SectorName = remapped.Sector.Name
+				if remapped.IndustryGroup != nil {
+					weight.IndustryGroupCode = remapped.IndustryGroup.Code
+					weight.IndustryGroupName = remapped.IndustryGroup.Name
+				}
+			}
+		}
+
+		key := weight.SectorCode + "/" + weight.IndustryGroupCode
+		if existing, ok := weights[key]; ok {
+			existing.Weight = existing.Weight.Add(sector.FundPercent)
+			continue
+		}
+
+		weight.Weight = sector.FundPercent
+		weights[key] = weight
+		fundSectors.Sectors = append(fundSectors.Sectors, weight)
+	}
+
+	sort.SliceStable(fundSectors.Sectors, func(i, j int) bool {
+		return fundSectors.Sectors[i].Weight.GreaterThan(fundSectors.Sectors[j].Weight)
+	})
+
+	return fundSectors
+}
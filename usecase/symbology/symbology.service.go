@@ -0,0 +1,42 @@
+package symbology
+
+import (
+	"context"
+	"errors"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+// ErrNotFound is returned when no fund matches the symbol
+var ErrNotFound = errors.New("fund not found")
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetSymbology gets the identifiers of a fund looked up by a ticker of any vendor format, an ISIN or a SEDOL
+func (s *Service) GetSymbology(ctx context.Context, symbol string) (*entities.Symbology, error) {
+	s.log.Info(ctx, "get symbology", "symbol", symbol)
+
+	overview, err := s.repo.FindFundOverviewBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if overview == nil {
+		return nil, ErrNotFound
+	}
+
+	return overview.Symbology, nil
+}
@@ -0,0 +1,102 @@
+package tax
+
+import (
+	"strings"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+// distCodeCategories maps Vanguard distribution codes to tax categories
+var distCodeCategories = map[string]string{
+	"DIV":   entities.TAX_CATEGORY_INCOME,
+	"INC":   entities.TAX_CATEGORY_INCOME,
+	"ID":    entities.TAX_CATEGORY_INCOME,
+	"EDIV":  entities.TAX_CATEGORY_ELIGIBLE_DIVIDEND,
+	"FOR":   entities.TAX_CATEGORY_FOREIGN_INCOME,
+	"FI":    entities.TAX_CATEGORY_FOREIGN_INCOME,
+	"INT":   entities.TAX_CATEGORY_INTEREST,
+	"CG":    entities.TAX_CATEGORY_CAPITAL_GAIN,
+	"CAPG":  entities.TAX_CATEGORY_CAPITAL_GAIN,
+	"LTCG":  entities.TAX_CATEGORY_CAPITAL_GAIN,
+	"STCG":  entities.TAX_CATEGORY_CAPITAL_GAIN,
+	"ROC":   entities.TAX_CATEGORY_RETURN_OF_CAPITAL,
+	"RC":    entities.TAX_CATEGORY_RETURN_OF_CAPITAL,
+	"RCG":   entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN,
+	"NCG":   entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN,
+	"REINV": entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN,
+}
+
+// keywordCategories maps keywords of distribution description or type to tax categories, the first match wins
+var keywordCategories = []struct {
+	keywords []string
+	category string
+}{
+	{
+		keywords: []string{"REINVESTED", "NOTIONAL", "NON-CASH", "NON CASH"},
+		category: entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN,
+	},
+	{
+		keywords: []string{"RETURN OF CAPITAL"},
+		category: entities.TAX_CATEGORY_RETURN_OF_CAPITAL,
+	},
+	{
+		keywords: []string{"CAPITAL GAIN", "CAP GAIN"},
+		category: entities.TAX_CATEGORY_CAPITAL_GAIN,
+	},
+	{
+		keywords: []string{"ELIGIBLE DIVIDEND"},
+		category: entities.TAX_CATEGORY_ELIGIBLE_DIVIDEND,
+	},
+	{
+		keywords: []string{"FOREIGN"},
+		category: entities.TAX_CATEGORY_FOREIGN_INCOME,
+	},
+	{
+		keywords: []string{"INTEREST"},
+		category: entities.TAX_CATEGORY_INTEREST,
+	},
+	{
+		keywords: []string{"DIVIDEND", "INCOME"},
+		category: entities.TAX_CATEGORY_INCOME,
+	},
+}
+
+// Classify maps a distribution to its tax category. The distribution code is used first,
+// then keywords of the description and type.
+func Classify(distCode, distDesc, distType string) string {
+	if category, ok := distCodeCategories[strings.ToUpper(strings.TrimSpace(distCode))]; ok {
+		return category
+	}
+
+	text := strings.ToUpper(distDesc + " " + distType)
+	for _, rule := range keywordCategories {
+		for _, keyword := range rule.keywords {
+			if strings.Contains(text, keyword) {
+				return rule.category
+			}
+		}
+	}
+
+	return entities.TAX_CATEGORY_UNKNOWN
+}
+
+// ClassifyDistribution maps a stored distribution history to its tax category
+func ClassifyDistribution(history *entities.DistributionHistoryRecord) string {
+	return Classify(history.DistCode, history.DistDesc, history.Type)
+}
+
+// IsNotional checks if distributions of a tax category are reinvested instead of paid in cash
+func IsNotional(category string) bool {
+	return category == entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN
+}
+
+// IsSpecialDistribution checks if a distribution is a special one, e.g. a year-end capital gain distribution,
+// rather than a regular income distribution
+func IsSpecialDistribution(history *entities.DistributionHistoryRecord) bool {
+	switch ClassifyDistribution(history) {
+	case entities.TAX_CATEGORY_CAPITAL_GAIN, entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN:
+		return true
+	}
+
+	return strings.Contains(strings.ToUpper(history.DistDesc+" "+history.Type), "SPECIAL")
+}
@@ -0,0 +1,28 @@
+package tax
+
+import (
+	"context"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+)
+
+///////////////////////////////////////////////////////////
+// Tax Repository Interface
+///////////////////////////////////////////////////////////
+
+// Reader interface
+type Reader interface {
+	FindFundOverviews(ctx context.Context) ([]*entities.FundOverviewRecord, error)
+	FindFundOverviewsByTickers(ctx context.Context, tickers []string) ([]*entities.FundOverviewRecord, error)
+	FindFundDistributions(ctx context.Context) ([]*entities.FundDistributionRecord, error)
+	FindFundDistributionsByTickers(ctx context.Context, tickers []string) ([]*entities.FundDistributionRecord, error)
+}
+
+// Writer interface
+type Writer interface{}
+
+// Repo interface
+type Repo interface {
+	Reader
+	Writer
+}
@@ -0,0 +1,209 @@
+package tax
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// Federal gross-up and dividend tax credit rates of eligible dividends
+var (
+	eligibleDividendGrossUp    = decimal.RequireFromString("0.38")
+	eligibleDividendCreditRate = decimal.RequireFromString("0.150198")
+)
+
+// canadaCountryCode is the country code of Canada in the overview country breakdown
+const canadaCountryCode = "CAN"
+
+// percentOfWholeFund is the total weight of a fund, breakdown weights are stored as 0-100 values
+var percentOfWholeFund = decimal.NewFromInt(100)
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetTaxSummaries approximates the T3 boxes of the given funds for a tax year, all funds if no ticker is given.
+// Vanguard publishes the exact tax character of a year only after year end, so income distributions are split
+// using the fund allocation and country exposure: the stock part is eligible dividends for its Canadian exposure
+// and foreign income for the rest, the bond and cash part is other income.
+func (s *Service) GetTaxSummaries(ctx context.Context, tickers []string, year int) ([]*entities.TaxSummary, error) {
+	s.log.Info(ctx, "get tax summaries", "tickers", tickers, "year", year)
+
+	var err error
+	var overviews []*entities.FundOverviewRecord
+	var fundDistributions []*entities.FundDistributionRecord
+
+	if len(tickers) == 0 {
+		if overviews, err = s.repo.FindFundOverviews(ctx); err != nil {
+			return nil, err
+		}
+
+		if fundDistributions, err = s.repo.FindFundDistributions(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		var yahooTickers []string
+		for _, t := range tickers {
+			yahooTickers = append(yahooTickers, ticker.NormalizeYahooTicker(t))
+		}
+
+		if overviews, err = s.repo.FindFundOverviewsByTickers(ctx, yahooTickers); err != nil {
+			return nil, err
+		}
+
+		if fundDistributions, err = s.repo.FindFundDistributionsByTickers(ctx, yahooTickers); err != nil {
+			return nil, err
+		}
+	}
+
+	overviewsByTicker := make(map[string]*entities.FundOverviewRecord)
+	for _, overview := range overviews {
+		overviewsByTicker[overview.Ticker] = overview
+	}
+
+	var summaries []*entities.TaxSummary
+	for _, fundDistribution := range fundDistributions {
+		overview, ok := overviewsByTicker[fundDistribution.Ticker]
+		if !ok {
+			s.log.Warn(ctx, "fund overview not found", "ticker", fundDistribution.Ticker)
+			overview = &entities.FundOverviewRecord{Ticker: fundDistribution.Ticker}
+		}
+
+		summaries = append(summaries, s.newTaxSummary(ctx, overview, fundDistribution, year))
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Ticker < summaries[j].Ticker
+	})
+
+	return summaries, nil
+}
+
+// newTaxSummary classifies the distributions of a tax year and sums them into T3 boxes
+func (s *Service) newTaxSummary(ctx context.Context, overview *entities.FundOverviewRecord, fundDistribution *entities.FundDistributionRecord, year int) *entities.TaxSummary {
+	summary := &entities.TaxSummary{
+		Ticker: overview.Ticker,
+		Name:   overview.Name,
+		Year:   year,
+	}
+
+	stockShare := getStockShare(overview)
+	canadaShare := getCanadaShare(overview)
+
+	for _, history := range fundDistribution.DistributionHistories {
+		// distributions belong to the tax year of their record date
+		recordDate := history.RecordDate
+		if recordDate == nil {
+			recordDate = history.ExDividendDate
+		}
+
+		if recordDate == nil {
+			s.log.Warn(ctx, "distribution has no valid date", "ticker", fundDistribution.Ticker, "type", history.Type, "distCode", history.DistCode)
+			continue
+		}
+
+		if recordDate.Year() != year {
+			continue
+		}
+
+		category := ClassifyDistribution(history)
+		amount := history.DistributionAmount
+
+		summary.Distributions = append(summary.Distributions, &entities.ClassifiedDistribution{
+			Type:        history.Type,
+			DistCode:    history.DistCode,
+			DistDesc:    history.DistDesc,
+			Amount:      amount,
+			RecordDate:  recordDate,
+			TaxCategory: category,
+		})
+
+		if !IsNotional(category) {
+			summary.TotalCash = summary.TotalCash.Add(amount)
+		}
+
+		switch category {
+		case entities.TAX_CATEGORY_ELIGIBLE_DIVIDEND:
+			summary.EligibleDividends = summary.EligibleDividends.Add(amount)
+		case entities.TAX_CATEGORY_FOREIGN_INCOME:
+			summary.ForeignIncome = summary.ForeignIncome.Add(amount)
+		case entities.TAX_CATEGORY_INTEREST:
+			summary.OtherIncome = summary.OtherIncome.Add(amount)
+		case entities.TAX_CATEGORY_CAPITAL_GAIN:
+			summary.CapitalGains = summary.CapitalGains.Add(amount)
+		case entities.TAX_CATEGORY_RETURN_OF_CAPITAL:
+			summary.ReturnOfCapital = summary.ReturnOfCapital.Add(amount)
+		case entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN:
+			summary.CapitalGains = summary.CapitalGains.Add(amount)
+			summary.ReinvestedCapitalGains = summary.ReinvestedCapitalGains.Add(amount)
+		case entities.TAX_CATEGORY_INCOME, entities.TAX_CATEGORY_UNKNOWN:
+			stockAmount := amount.Mul(stockShare)
+			canadianAmount := stockAmount.Mul(canadaShare)
+
+			summary.EligibleDividends = summary.EligibleDividends.Add(canadianAmount)
+			summary.ForeignIncome = summary.ForeignIncome.Add(stockAmount.Sub(canadianAmount))
+			summary.OtherIncome = summary.OtherIncome.Add(amount.Sub(stockAmount))
+		}
+	}
+
+	summary.TaxableEligibleDividends = summary.EligibleDividends.Add(summary.EligibleDividends.Mul(eligibleDividendGrossUp))
+	summary.DividendTaxCredit = summary.TaxableEligibleDividends.Mul(eligibleDividendCreditRate)
+
+	// reinvested capital gains increase the adjusted cost base, return of capital reduces it
+	summary.AcbAdjustment = summary.ReinvestedCapitalGains.Sub(summary.ReturnOfCapital)
+
+	return summary
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// getStockShare gets the stock part of a fund between 0 and 1
+func getStockShare(overview *entities.FundOverviewRecord) decimal.Decimal {
+	if total := decimal.Sum(overview.AllocationStock, overview.AllocationBond, overview.AllocationCash); total.IsPositive() {
+		return overview.AllocationStock.Div(total)
+	}
+
+	switch strings.ToUpper(overview.AssetClass) {
+	case consts.BOND:
+		return decimal.Zero
+	default:
+		return decimal.NewFromInt(1)
+	}
+}
+
+// getCanadaShare gets the Canadian part of a fund country exposure between 0 and 1.
+// A fund without country exposure is assumed to be Canadian.
+func getCanadaShare(overview *entities.FundOverviewRecord) decimal.Decimal {
+	total, canada := decimal.Zero, decimal.Zero
+	for _, country := range overview.Countries {
+		total = total.Add(country.FundMktPercent)
+
+		if country.CountryCode == canadaCountryCode {
+			canada = canada.Add(country.FundMktPercent)
+		}
+	}
+
+	if !total.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+
+	return canada.Div(decimal.Max(total, percentOfWholeFund))
+}
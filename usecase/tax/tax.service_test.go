@@ -0,0 +1,116 @@
+package tax
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+type fakeRepo struct {
+	overviews     []*entities.FundOverviewRecord
+	distributions []*entities.FundDistributionRecord
+}
+
+func (r *fakeRepo) FindFundOverviews(ctx context.Context) ([]*entities.FundOverviewRecord, error) {
+	return r.overviews, nil
+}
+
+func (r *fakeRepo) FindFundOverviewsByTickers(ctx context.Context, tickers []string) ([]*entities.FundOverviewRecord, error) {
+	return r.overviews, nil
+}
+
+func (r *fakeRepo) FindFundDistributions(ctx context.Context) ([]*entities.FundDistributionRecord, error) {
+	return r.distributions, nil
+}
+
+func (r *fakeRepo) FindFundDistributionsByTickers(ctx context.Context, tickers []string) ([]*entities.FundDistributionRecord, error) {
+	return r.distributions, nil
+}
+
+func businessDate(date string) *time.Time {
+	t, _ := datetime.ParseDate(date)
+	return t
+}
+
+func TestGetTaxSummaries(t *testing.T) {
+	repo := &fakeRepo{
+		overviews: []*entities.FundOverviewRecord{{
+			Ticker:          "VBAL.TO",
+			AllocationStock: decimal.RequireFromString("90"),
+			AllocationBond:  decimal.RequireFromString("10"),
+			Countries: []*entities.CountryBreakdownRecord{
+				{CountryCode: "CAN", FundMktPercent: decimal.RequireFromString("30")},
+				{CountryCode: "USA", FundMktPercent: decimal.RequireFromString("70")},
+			},
+		}},
+		distributions: []*entities.FundDistributionRecord{{
+			Ticker: "VBAL.TO",
+			DistributionHistories: []*entities.DistributionHistoryRecord{
+				{Type: "Income", DistributionAmount: decimal.RequireFromString("1"), RecordDate: businessDate("2025-03-31")},
+				{DistCode: "EDIV", DistributionAmount: decimal.RequireFromString("0.5"), ExDividendDate: businessDate("2025-06-27")},
+				{DistDesc: "Return of capital", DistributionAmount: decimal.RequireFromString("0.1"), RecordDate: businessDate("2025-09-30")},
+				{DistCode: "RCG", DistributionAmount: decimal.RequireFromString("0.4"), RecordDate: businessDate("2025-12-31")},
+				// paid in the next tax year by record date
+				{Type: "Income", DistributionAmount: decimal.RequireFromString("2"), ExDividendDate: businessDate("2025-12-30"), RecordDate: businessDate("2026-01-02")},
+			},
+		}},
+	}
+
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	summaries, err := NewService(repo, log).GetTaxSummaries(context.Background(), nil, 2025)
+	if err != nil {
+		t.Fatalf("GetTaxSummaries error = %v", err)
+	}
+
+	if len(summaries) != 1 || len(summaries[0].Distributions) != 4 {
+		t.Fatalf("summaries = %+v, want one summary of four distributions", summaries)
+	}
+	summary := summaries[0]
+
+	// the income distribution is split 90% stock, of which 30% Canadian, and 10% bond
+	eligible := 0.5 + 1*0.9*0.3
+	boxes := map[string]struct {
+		got  decimal.Decimal
+		want float64
+	}{
+		"capital gains":              {summary.CapitalGains, 0.4},
+		"foreign income":             {summary.ForeignIncome, 0.9 * 0.7},
+		"other income":               {summary.OtherIncome, 0.1},
+		"return of capital":          {summary.ReturnOfCapital, 0.1},
+		"eligible dividends":         {summary.EligibleDividends, eligible},
+		"taxable eligible dividends": {summary.TaxableEligibleDividends, eligible * 1.38},
+		"dividend tax credit":        {summary.DividendTaxCredit, eligible * 1.38 * 0.150198},
+		"acb adjustment":             {summary.AcbAdjustment, 0.4 - 0.1},
+		"total cash":                 {summary.TotalCash, 1 + 0.5 + 0.1},
+	}
+
+	for box, values := range boxes {
+		if math.Abs(values.got.Float64()-values.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", box, values.got, values.want)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	if got := Classify(" ediv ", "Capital gain", ""); got != entities.TAX_CATEGORY_ELIGIBLE_DIVIDEND {
+		t.Errorf("the distribution code wins over the description, got %s", got)
+	}
+
+	if got := Classify("", "Reinvested capital gain", "Capital Gain"); got != entities.TAX_CATEGORY_REINVESTED_CAPITAL_GAIN || !IsNotional(got) {
+		t.Errorf("Classify of a reinvested capital gain = %s", got)
+	}
+
+	if got := Classify("XYZ", "", "Distribution"); got != entities.TAX_CATEGORY_UNKNOWN {
+		t.Errorf("Classify of an unknown distribution = %s", got)
+	}
+}
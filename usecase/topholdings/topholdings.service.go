@@ -0,0 +1,85 @@
+package topholdings
+
+import (
+	"context"
+	"errors"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// ErrNotFound is returned when the fund holding is not stored
+var ErrNotFound = errors.New("fund holding not found")
+
+// defaultTopHoldings is used when the number of top holdings is not given
+const defaultTopHoldings = 10
+
+// Service sector
+type Service struct {
+	repo Repo
+	log  logger.ContextLog
+}
+
+// NewService create new service
+func NewService(repo Repo, log logger.ContextLog) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetTopHoldings gets the n largest stock holdings of a fund by weight
+func (s *Service) GetTopHoldings(ctx context.Context, fundTicker string, n int) (*entities.TopHoldings, error) {
+	s.log.Info(ctx, "get top holdings", "ticker", fundTicker, "n", n)
+
+	if n <= 0 {
+		n = defaultTopHoldings
+	}
+
+	holding, err := s.repo.FindTopFundHolding(ctx, ticker.NormalizeYahooTicker(fundTicker), n)
+	if err != nil {
+		return nil, err
+	}
+
+	if holding == nil {
+		return nil, ErrNotFound
+	}
+
+	topHoldings := &entities.TopHoldings{
+		Ticker:    holding.Ticker,
+		AssetCode: holding.AssetCode,
+	}
+
+	for _, stock := range holding.Stocks {
+		if stock == nil {
+			continue
+		}
+
+		// a holding stored before stocks were ranked is not sorted
+		if stock.Rank == 0 {
+			s.log.Warn(ctx, "stock holdings are not ranked, run the schema migration", "ticker", holding.Ticker)
+			return topHoldings, nil
+		}
+
+		topHoldings.Holdings = append(topHoldings.Holdings, newTopHolding(stock))
+	}
+
+	return topHoldings, nil
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// newTopHolding creates a top holding from a stored stock
+func newTopHolding(stock *entities.SectorWeightStockRecord) *entities.TopHolding {
+	return &entities.TopHolding{
+		Rank:             stock.Rank,
+		Symbol:           stock.Symbol,
+		Type:             stock.Type,
+		MarketValPercent: stock.MarketValPercent,
+		MarketValue:      stock.MarketValue,
+		Shares:           stock.Shares,
+	}
+}
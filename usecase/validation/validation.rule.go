@@ -0,0 +1,251 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/identifier"
+)
+
+// Rule checks a value or a group of values of a scraped document, it returns nil when the check passes.
+// Empty raw values are missing data rather than broken data, so they pass.
+type Rule interface {
+	Check() *entities.ValidationIssue
+}
+
+// NumberRule checks a raw value is a number
+type NumberRule struct {
+	Field string
+	Value string
+}
+
+// Check implements Rule
+func (r NumberRule) Check() *entities.ValidationIssue {
+	if r.Value == "" {
+		return nil
+	}
+
+	if _, err := decimal.NewFromString(r.Value); err != nil {
+		return newIssue(entities.VALIDATION_RULE_NUMBER, entities.VALIDATION_SEVERITY_ERROR, r.Field, r.Value, "not a number")
+	}
+
+	return nil
+}
+
+// PercentRule checks a raw percentage is a number between 0 and 100
+type PercentRule struct {
+	Field string
+	Value string
+}
+
+// Check implements Rule
+func (r PercentRule) Check() *entities.ValidationIssue {
+	if r.Value == "" {
+		return nil
+	}
+
+	percent, err := decimal.NewFromString(r.Value)
+	if err != nil {
+		return newIssue(entities.VALIDATION_RULE_NUMBER, entities.VALIDATION_SEVERITY_ERROR, r.Field, r.Value, "not a number")
+	}
+
+	if percent.IsNegative() || percent.GreaterThan(decimal.NewFromInt(100)) {
+		return newIssue(entities.VALIDATION_RULE_PERCENT_RANGE, entities.VALIDATION_SEVERITY_ERROR, r.Field, r.Value, "percentage must be between 0 and 100")
+	}
+
+	return nil
+}
+
+// WeightSumRule checks raw weights add up to 100 percent give or take the tolerance.
+// Unparsable weights are left to the other rules and weights without any data are left to the caller.
+type WeightSumRule struct {
+	Field     string
+	Values    []string
+	Tolerance float64
+}
+
+// Check implements Rule
+func (r WeightSumRule) Check() *entities.ValidationIssue {
+	sum := decimal.Zero
+	for _, value := range r.Values {
+		if weight, err := decimal.NewFromString(value); err == nil {
+			sum = sum.Add(weight)
+		}
+	}
+
+	if sum.IsZero() {
+		return nil
+	}
+
+	if sum.Sub(decimal.NewFromInt(100)).Abs().GreaterThan(decimal.NewFromFloat(r.Tolerance)) {
+		return newIssue(entities.VALIDATION_RULE_WEIGHT_SUM, entities.VALIDATION_SEVERITY_ERROR, r.Field, sum.String(), fmt.Sprintf("weights must sum to 100 +/- %g", r.Tolerance))
+	}
+
+	return nil
+}
+
+// NonNegativeRule checks a number is not negative
+type NonNegativeRule struct {
+	Field string
+	Value decimal.Decimal
+}
+
+// Check implements Rule
+func (r NonNegativeRule) Check() *entities.ValidationIssue {
+	if r.Value.IsNegative() {
+		return newIssue(entities.VALIDATION_RULE_NON_NEGATIVE, entities.VALIDATION_SEVERITY_ERROR, r.Field, r.Value.String(), "must not be negative")
+	}
+
+	return nil
+}
+
+// PositiveRule checks a number is greater than zero, e.g. a price of 0 is a broken quote rather than a free fund.
+// A missing number is reported as absent, it does not fail the rule.
+type PositiveRule struct {
+	Field string
+	Value decimal.NullDecimal
+}
+
+// Check implements Rule
+func (r PositiveRule) Check() *entities.ValidationIssue {
+	if !r.Value.Valid {
+		return newIssue(entities.VALIDATION_RULE_PRESENT, entities.VALIDATION_SEVERITY_ABSENT, r.Field, "", "not published")
+	}
+
+	if !r.Value.Decimal.IsPositive() {
+		return newIssue(entities.VALIDATION_RULE_POSITIVE, entities.VALIDATION_SEVERITY_ERROR, r.Field, r.Value.Decimal.String(), "must be greater than zero")
+	}
+
+	return nil
+}
+
+// NotEmptyRule checks a list which every fund of the kind publishes has at least one item
+type NotEmptyRule struct {
+	Field string
+	Count int
+}
+
+// Check implements Rule
+func (r NotEmptyRule) Check() *entities.ValidationIssue {
+	if r.Count == 0 {
+		return newIssue(entities.VALIDATION_RULE_NOT_EMPTY, entities.VALIDATION_SEVERITY_ERROR, r.Field, "", "must not be empty")
+	}
+
+	return nil
+}
+
+// IsinRule checks the format and the check digit of an ISIN
+type IsinRule struct {
+	Field string
+	Value string
+}
+
+// Check implements Rule
+func (r IsinRule) Check() *entities.ValidationIssue {
+	if r.Value == "" {
+		return nil
+	}
+
+	if !identifier.IsValidIsin(r.Value) {
+		return newIssue(entities.VALIDATION_RULE_ISIN_CHECKSUM, entities.VALIDATION_SEVERITY_ERROR, r.Field, r.Value, "invalid ISIN")
+	}
+
+	return nil
+}
+
+// IsinCountryRule checks the country prefix of a valid ISIN is the domicile of the fund
+type IsinCountryRule struct {
+	Field    string
+	Value    string
+	Domicile string
+}
+
+// Check implements Rule
+func (r IsinCountryRule) Check() *entities.ValidationIssue {
+	country := identifier.GetIsinCountry(r.Value)
+	if country == "" {
+		return nil
+	}
+
+	if country != r.Domicile {
+		return newIssue(entities.VALIDATION_RULE_ISIN_COUNTRY, entities.VALIDATION_SEVERITY_WARNING, r.Field, r.Value, fmt.Sprintf("ISIN country %s does not match domicile %s", country, r.Domicile))
+	}
+
+	return nil
+}
+
+// SedolRule checks the format and the check digit of a SEDOL
+type SedolRule struct {
+	Field string
+	Value string
+}
+
+// Check implements Rule
+func (r SedolRule) Check() *entities.ValidationIssue {
+	if r.Value == "" {
+		return nil
+	}
+
+	if !identifier.IsValidSedol(r.Value) {
+		return newIssue(entities.VALIDATION_RULE_SEDOL_CHECKSUM, entities.VALIDATION_SEVERITY_ERROR, r.Field, r.Value, "invalid SEDOL")
+	}
+
+	return nil
+}
+
+// CurrencyRule checks a currency is a known ISO 4217 code
+type CurrencyRule struct {
+	Field string
+	Value string
+}
+
+// Check implements Rule
+func (r CurrencyRule) Check() *entities.ValidationIssue {
+	if r.Value == "" {
+		return nil
+	}
+
+	if !consts.CurrencyCodes[strings.ToUpper(r.Value)] {
+		return newIssue(entities.VALIDATION_RULE_CURRENCY_CODE, entities.VALIDATION_SEVERITY_WARNING, r.Field, r.Value, "unknown currency code")
+	}
+
+	return nil
+}
+
+// DateRule checks a raw date parses. An unparsable date is a warning because the document is stored without it.
+type DateRule struct {
+	Field string
+	Value string
+}
+
+// Check implements Rule
+func (r DateRule) Check() *entities.ValidationIssue {
+	if r.Value == "" {
+		return nil
+	}
+
+	if _, err := datetime.ParseDate(r.Value); err != nil {
+		return newIssue(entities.VALIDATION_RULE_DATE, entities.VALIDATION_SEVERITY_WARNING, r.Field, r.Value, "invalid date")
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// newIssue creates a validation issue
+func newIssue(rule, severity, field, value, message string) *entities.ValidationIssue {
+	return &entities.ValidationIssue{
+		Rule:     rule,
+		Severity: severity,
+		Field:    field,
+		Value:    value,
+		Message:  message,
+	}
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+func TestPositiveRule(t *testing.T) {
+	tests := []struct {
+		value    decimal.NullDecimal
+		severity string
+	}{
+		{decimal.NullDecimal{}, entities.VALIDATION_SEVERITY_ABSENT},
+		{decimal.NewNullDecimal(decimal.Zero), entities.VALIDATION_SEVERITY_ERROR},
+		{decimal.NewNullDecimal(decimal.New(-1, -2)), entities.VALIDATION_SEVERITY_ERROR},
+		{decimal.NewNullDecimal(decimal.New(4215, -2)), ""},
+	}
+
+	for _, test := range tests {
+		var severity string
+		if issue := (PositiveRule{Field: "price", Value: test.value}).Check(); issue != nil {
+			severity = issue.Severity
+		}
+
+		if severity != test.severity {
+			t.Errorf("PositiveRule of %v = %q, want %q", test.value.Ptr(), severity, test.severity)
+		}
+	}
+}
+
+func TestIsBondAssetClass(t *testing.T) {
+	tests := map[string]bool{
+		"BOND":          true,
+		"bond":          true,
+		"Bonds":         true,
+		"Fixed Income":  true,
+		"FIXED_INCOME":  true,
+		"fixed-income ": true,
+		"EQUITY":        false,
+		"BALANCED":      false,
+		"Bond Ladder":   false,
+		"":              false,
+	}
+
+	for assetClass, want := range tests {
+		if got := isBondAssetClass(assetClass); got != want {
+			t.Errorf("isBondAssetClass(%q) = %v, want %v", assetClass, got, want)
+		}
+	}
+}
+
+func TestValidateFundOverviewWithoutPrice(t *testing.T) {
+	service := newTestService(t)
+	service.policy.OnWarning = entities.VALIDATION_ACTION_QUARANTINE
+
+	// a bond fund without a sector weighting and a quote is still stored
+	result := service.ValidateFundOverview(context.Background(), &entities.FundOverview{PortID: "9561", AssetClass: "Fixed Income"})
+	if result.Action != entities.VALIDATION_ACTION_STORE || len(result.Issues) != 1 || result.Issues[0].Rule != entities.VALIDATION_RULE_PRESENT {
+		t.Errorf("overview without price = %s %+v, want stored with the price absent", result.Action, result.Issues)
+	}
+}
@@ -0,0 +1,317 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/config"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/runid"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/ticker"
+)
+
+// ErrRejected is returned when the validation policy rejects a document
+var ErrRejected = errors.New("document rejected by validation")
+
+// ErrQuarantined is returned when the validation policy quarantines a document, the stored document is kept
+var ErrQuarantined = errors.New("document quarantined by validation")
+
+// QuarantineWriter stores documents quarantined by validation
+type QuarantineWriter interface {
+	InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error
+}
+
+// Service sector
+type Service struct {
+	policy *config.ValidationConfig
+	log    logger.ContextLog
+
+	// scraper jobs run concurrently
+	mu      sync.Mutex
+	results []*entities.ValidationResult
+}
+
+// NewService create new service, the policy actions must be STORE, QUARANTINE or REJECT
+func NewService(policy *config.ValidationConfig, log logger.ContextLog) (*Service, error) {
+	for _, action := range []string{policy.OnError, policy.OnWarning} {
+		switch action {
+		case entities.VALIDATION_ACTION_STORE, entities.VALIDATION_ACTION_QUARANTINE, entities.VALIDATION_ACTION_REJECT:
+		default:
+			return nil, fmt.Errorf("invalid validation action %q", action)
+		}
+	}
+
+	if policy.WeightSumTolerance < 0 {
+		return nil, fmt.Errorf("invalid weight sum tolerance %g", policy.WeightSumTolerance)
+	}
+
+	return &Service{
+		policy: policy,
+		log:    log,
+	}, nil
+}
+
+// ValidateFund validates a fund of the fund list
+func (s *Service) ValidateFund(ctx context.Context, fund *entities.Fund) *entities.ValidationResult {
+	rules := []Rule{
+		PercentRule{Field: "managementFee", Value: fund.ManagementFee},
+		PercentRule{Field: "merValue", Value: fund.MerFee},
+		CurrencyRule{Field: "currency", Value: fund.Currency},
+	}
+
+	return s.validate(ctx, fund.Ticker, fund.PortID, entities.VALIDATION_KIND_FUND, rules)
+}
+
+// ValidateFundOverview validates a fund overview
+func (s *Service) ValidateFundOverview(ctx context.Context, fundOverview *entities.FundOverview) *entities.ValidationResult {
+	rules := []Rule{
+		NumberRule{Field: "totalAssets", Value: fundOverview.TotalAssets},
+		PercentRule{Field: "yield12Month", Value: fundOverview.Yield12Month},
+		PercentRule{Field: "managementFee", Value: fundOverview.ManagementFee},
+		PercentRule{Field: "merValue", Value: fundOverview.MerFee},
+		PercentRule{Field: "distYield", Value: fundOverview.DistYield},
+		NumberRule{Field: "incomeDistributionAmount", Value: fundOverview.DistAmount},
+		PositiveRule{Field: "price", Value: fundOverview.Price},
+		CurrencyRule{Field: "baseCurrency", Value: fundOverview.BaseCurrency},
+		PercentRule{Field: "allocationStock", Value: fundOverview.AllocationStock.String()},
+		PercentRule{Field: "allocationBond", Value: fundOverview.AllocationBond.String()},
+		PercentRule{Field: "allocationCash", Value: fundOverview.AllocationCash.String()},
+		WeightSumRule{
+			Field:     "allocation",
+			Values:    []string{fundOverview.AllocationStock.String(), fundOverview.AllocationBond.String(), fundOverview.AllocationCash.String()},
+			Tolerance: s.policy.WeightSumTolerance,
+		},
+	}
+
+	var vanguardTicker string
+	if fundOverview.FundCode != nil {
+		vanguardTicker = fundOverview.FundCode.ExchangeTicker
+		rules = append(rules,
+			IsinRule{Field: "fundCodesData.isin", Value: fundOverview.FundCode.Isin},
+			IsinCountryRule{Field: "fundCodesData.isin", Value: fundOverview.FundCode.Isin, Domicile: consts.FUND_DOMICILE},
+			SedolRule{Field: "fundCodesData.sedol", Value: fundOverview.FundCode.Sedol},
+		)
+	}
+
+	// sectors fall back to the benchmark weight like the overview model does
+	var sectorWeights []string
+	for i, sector := range fundOverview.Sectors {
+		weight := sector.FundPercent
+		if percent, _ := decimal.NewFromString(weight); percent.IsZero() && sector.BenchmarkPercent != "" {
+			weight = sector.BenchmarkPercent
+		}
+
+		sectorWeights = append(sectorWeights, weight)
+		rules = append(rules, PercentRule{Field: fmt.Sprintf("sectorWeighting[%d].fundPercent", i), Value: weight})
+	}
+	rules = append(rules, WeightSumRule{Field: "sectorWeighting", Values: sectorWeights, Tolerance: s.policy.WeightSumTolerance})
+
+	// bond funds do not publish a sector weighting
+	if !isBondAssetClass(fundOverview.AssetClass) {
+		rules = append(rules, NotEmptyRule{Field: "sectorWeighting", Count: len(fundOverview.Sectors)})
+	}
+
+	var countryWeights []string
+	for i, country := range fundOverview.Countries {
+		countryWeights = append(countryWeights, country.FundMktPercent)
+		rules = append(rules,
+			PercentRule{Field: fmt.Sprintf("countryExposure[%d].fundMktPercent", i), Value: country.FundMktPercent},
+			PercentRule{Field: fmt.Sprintf("countryExposure[%d].fundTnaPercent", i), Value: country.FundTnaPercent},
+		)
+	}
+	rules = append(rules, WeightSumRule{Field: "countryExposure", Values: countryWeights, Tolerance: s.policy.WeightSumTolerance})
+
+	for i, dividend := range fundOverview.Dividends {
+		rules = append(rules,
+			NumberRule{Field: fmt.Sprintf("distHistory[%d].amount", i), Value: dividend.Amount},
+			CurrencyRule{Field: fmt.Sprintf("distHistory[%d].currencyCode", i), Value: dividend.CurrencyCode},
+			DateRule{Field: fmt.Sprintf("distHistory[%d].asOfDate", i), Value: dividend.AsOfDate},
+		)
+	}
+
+	return s.validate(ctx, vanguardTicker, fundOverview.PortID, entities.VALIDATION_KIND_OVERVIEW, rules)
+}
+
+// ValidateFundHolding validates a fund holding. Holding weights can be negative, e.g. cash of a fund using derivatives,
+// and a holding list may be partial so they are not checked, they are decimals so they are numbers already.
+func (s *Service) ValidateFundHolding(ctx context.Context, fundHolding *entities.FundHolding) *entities.ValidationResult {
+
+	var bonds []*entities.SectorWeightBond
+	var stocks []*entities.SectorWeightStock
+
+	for _, bond := range fundHolding.Bonds {
+		bonds = append(bonds, bond.SectorWeightBonds...)
+	}
+
+	for _, equity := range fundHolding.Equities {
+		stocks = append(stocks, equity.SectorWeightStocks...)
+	}
+
+	for _, balance := range fundHolding.Balances {
+		bonds = append(bonds, balance.SectorWeightBonds...)
+		stocks = append(stocks, balance.SectorWeightStocks...)
+	}
+
+	rules := []Rule{
+		NotEmptyRule{Field: "holdings", Count: len(bonds) + len(stocks)},
+	}
+
+	for i, bond := range bonds {
+		rules = append(rules, NonNegativeRule{Field: fmt.Sprintf("sectorWeightBond[%d].faceAmount", i), Value: bond.FaceAmount})
+	}
+
+	return s.validate(ctx, fundHolding.Ticker, fundHolding.PortID, entities.VALIDATION_KIND_HOLDING, rules)
+}
+
+// ValidateFundDistribution validates a fund distribution
+func (s *Service) ValidateFundDistribution(ctx context.Context, fundDistribution *entities.FundDistribution) *entities.ValidationResult {
+	var rules []Rule
+
+	details := fundDistribution.DistributionDetails
+	for i, history := range details.DistributionHistories {
+		rules = append(rules,
+			NonNegativeRule{Field: fmt.Sprintf("fundDistributionList[%d].distributionAmount", i), Value: history.DistributionAmount},
+			DateRule{Field: fmt.Sprintf("fundDistributionList[%d].exDividendDate", i), Value: history.ExDividendDate},
+			DateRule{Field: fmt.Sprintf("fundDistributionList[%d].recordDate", i), Value: history.RecordDate},
+			DateRule{Field: fmt.Sprintf("fundDistributionList[%d].payableDate", i), Value: history.PayableDate},
+		)
+	}
+
+	return s.validate(ctx, details.Ticker, details.PortID, entities.VALIDATION_KIND_DISTRIBUTION, rules)
+}
+
+// ValidateFundPerformance validates a fund performance, returns can be negative and a dash is a return not published
+func (s *Service) ValidateFundPerformance(ctx context.Context, fundPerformance *entities.FundPerformance) *entities.ValidationResult {
+	rules := []Rule{
+		DateRule{Field: "asOfDate", Value: fundPerformance.AsOfDate},
+		DateRule{Field: "inceptionDate", Value: fundPerformance.InceptionDate},
+	}
+
+	for _, series := range []struct {
+		field   string
+		returns *entities.TrailingReturns
+	}{
+		{"navReturns", fundPerformance.NavReturns},
+		{"marketPriceReturns", fundPerformance.MarketPriceReturns},
+		{"benchmarkReturns", fundPerformance.BenchmarkReturns},
+	} {
+		if series.returns == nil {
+			continue
+		}
+
+		for _, period := range []struct {
+			field string
+			value string
+		}{
+			{"oneMonth", series.returns.OneMonth},
+			{"threeMonth", series.returns.ThreeMonth},
+			{"yearToDate", series.returns.YearToDate},
+			{"oneYear", series.returns.OneYear},
+			{"threeYear", series.returns.ThreeYear},
+			{"fiveYear", series.returns.FiveYear},
+			{"tenYear", series.returns.TenYear},
+			{"sinceInception", series.returns.SinceInception},
+		} {
+			if entities.IsUnpublishedReturn(period.value) {
+				continue
+			}
+
+			rules = append(rules, NumberRule{Field: fmt.Sprintf("%s.%s", series.field, period.field), Value: strings.TrimSpace(period.value)})
+		}
+	}
+
+	return s.validate(ctx, fundPerformance.Ticker, fundPerformance.PortID, entities.VALIDATION_KIND_PERFORMANCE, rules)
+}
+
+// GetResults gets the validation results recorded so far sorted by ticker and kind
+func (s *Service) GetResults() []*entities.ValidationResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := append([]*entities.ValidationResult{}, s.results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Ticker != results[j].Ticker {
+			return results[i].Ticker < results[j].Ticker
+		}
+		return results[i].Kind < results[j].Kind
+	})
+
+	return results
+}
+
+// Enforce applies the action of a validation result before the document is stored. A quarantined document is
+// written to the quarantine store with its failed rules and the run id instead, so the stored document is kept.
+// The error returned instead of storing the document is ErrRejected or ErrQuarantined.
+func Enforce(ctx context.Context, writer QuarantineWriter, result *entities.ValidationResult, item *entities.QuarantinedItem) error {
+	switch result.Action {
+	case entities.VALIDATION_ACTION_REJECT:
+		return ErrRejected
+	case entities.VALIDATION_ACTION_QUARANTINE:
+		item.RunID = runid.FromContext(ctx)
+		item.Kind = result.Kind
+		item.Ticker = result.Ticker
+		item.PortID = result.PortID
+		item.Issues = result.Issues
+
+		if err := writer.InsertQuarantinedItem(ctx, item); err != nil {
+			return err
+		}
+
+		return ErrQuarantined
+	default:
+		return nil
+	}
+}
+
+// validate runs the rules, decides the action from the policy and records the result
+func (s *Service) validate(ctx context.Context, vanguardTicker, portID, kind string, rules []Rule) *entities.ValidationResult {
+	result := &entities.ValidationResult{
+		PortID: portID,
+		Kind:   kind,
+		Action: entities.VALIDATION_ACTION_STORE,
+	}
+
+	if vanguardTicker != "" {
+		result.Ticker = ticker.GenYahooTickerFromVanguardTicker(vanguardTicker)
+	}
+
+	for _, rule := range rules {
+		if issue := rule.Check(); issue != nil {
+			result.Issues = append(result.Issues, issue)
+		}
+	}
+
+	if result.HasErrors() {
+		result.Action = s.policy.OnError
+	} else if result.HasWarnings() {
+		result.Action = s.policy.OnWarning
+	}
+
+	if result.HasErrors() || result.HasWarnings() {
+		s.log.Warn(ctx, "validation failed", "ticker", result.Ticker, "portId", portID, "kind", kind, "action", result.Action, "issues", len(result.Issues))
+	}
+
+	s.mu.Lock()
+	s.results = append(s.results, result)
+	s.mu.Unlock()
+
+	return result
+}
+
+// isBondAssetClass checks an asset class names bonds whatever its case or spelling, e.g. "Bond", "BONDS" or
+// "Fixed Income"
+func isBondAssetClass(assetClass string) bool {
+	words := strings.FieldsFunc(strings.ToUpper(assetClass), func(r rune) bool {
+		return r < 'A' || r > 'Z'
+	})
+
+	normalized := strings.Join(words, " ")
+	return normalized == consts.BOND || normalized == consts.BOND+"S" || strings.Contains(normalized, "FIXED INCOME")
+}
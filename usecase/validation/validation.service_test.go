@@ -0,0 +1,111 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logger "github.com/lenoobz/aws-lambda-logger"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/config"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/entities"
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/decimal"
+)
+
+// fakeWriter keeps quarantined items in memory
+type fakeWriter struct {
+	items []*entities.QuarantinedItem
+}
+
+func (w *fakeWriter) InsertQuarantinedItem(ctx context.Context, item *entities.QuarantinedItem) error {
+	w.items = append(w.items, item)
+	return nil
+}
+
+func newTestService(t *testing.T) *Service {
+	log, err := logger.NewZapLogger()
+	if err != nil {
+		t.Fatalf("create logger failed: %v", err)
+	}
+
+	service, err := NewService(&config.ValidationConfig{
+		OnError:            entities.VALIDATION_ACTION_QUARANTINE,
+		OnWarning:          entities.VALIDATION_ACTION_STORE,
+		WeightSumTolerance: 0.5,
+	}, log)
+	if err != nil {
+		t.Fatalf("NewService error = %v", err)
+	}
+
+	return service
+}
+
+func TestValidateFundOverview(t *testing.T) {
+	service := newTestService(t)
+
+	valid := &entities.FundOverview{
+		PortID:          "9563",
+		MerFee:          "0.09",
+		Price:           decimal.NewNullDecimal(decimal.RequireFromString("128.5")),
+		BaseCurrency:    "CAD",
+		AllocationStock: decimal.RequireFromString("99.8"),
+		AllocationCash:  decimal.RequireFromString("0.2"),
+		FundCode:        &entities.FundCode{Isin: "CA92206C1007", ExchangeTicker: "VFV"},
+		// the second sector has no fund weight and falls back to the benchmark one
+		Sectors: []*entities.SectorBreakdown{
+			{FundPercent: "70.1", SectorName: "Information Technology"},
+			{FundPercent: "0", BenchmarkPercent: "29.6", SectorName: "Financials"},
+		},
+		Dividends: []*entities.DividendHistory{{Amount: "0.2", CurrencyCode: "CAD", AsOfDate: "2026-09-28T00:00:00-04:00"}},
+	}
+
+	result := service.ValidateFundOverview(context.Background(), valid)
+	if result.Ticker != "VFV.TO" || result.Action != entities.VALIDATION_ACTION_STORE || len(result.Issues) != 0 {
+		t.Errorf("valid overview = %s %s %+v, want VFV.TO stored without issue", result.Ticker, result.Action, result.Issues)
+	}
+
+	broken := *valid
+	broken.FundCode = &entities.FundCode{Isin: "CA92206C1008", ExchangeTicker: "VFV"}
+	broken.AllocationCash = decimal.NewFromInt(5)
+	broken.Dividends = []*entities.DividendHistory{{Amount: "0.2", CurrencyCode: "CAD", AsOfDate: "28/09/2026"}}
+
+	result = service.ValidateFundOverview(context.Background(), &broken)
+	writer := &fakeWriter{}
+	if err := Enforce(context.Background(), writer, result, &entities.QuarantinedItem{}); !errors.Is(err, ErrQuarantined) {
+		t.Errorf("Enforce of the broken overview error = %v, want ErrQuarantined", err)
+	}
+
+	if len(writer.items) != 1 || writer.items[0].Ticker != "VFV.TO" || writer.items[0].Kind != entities.VALIDATION_KIND_OVERVIEW || len(writer.items[0].Issues) != len(result.Issues) {
+		t.Errorf("quarantined items = %+v, want the VFV.TO overview with its issues", writer.items)
+	}
+
+	rules := make(map[string]string)
+	for _, issue := range result.Issues {
+		rules[issue.Rule] = issue.Field
+	}
+
+	if len(rules) != 3 || rules[entities.VALIDATION_RULE_ISIN_CHECKSUM] != "fundCodesData.isin" || rules[entities.VALIDATION_RULE_WEIGHT_SUM] != "allocation" || rules[entities.VALIDATION_RULE_DATE] != "distHistory[0].asOfDate" {
+		t.Errorf("broken overview issues = %v, want isin, allocation sum and date", rules)
+	}
+}
+
+func TestValidateFundWarningPolicy(t *testing.T) {
+	service := newTestService(t)
+
+	result := service.ValidateFund(context.Background(), &entities.Fund{Ticker: "VCN", PortID: "9555", Currency: "CDN", MerFee: "0.05"})
+	if len(result.Issues) != 1 || result.Issues[0].Severity != entities.VALIDATION_SEVERITY_WARNING || result.Action != entities.VALIDATION_ACTION_STORE {
+		t.Errorf("fund with unknown currency = %s %+v, want stored with one warning", result.Action, result.Issues)
+	}
+
+	writer := &fakeWriter{}
+	if err := Enforce(context.Background(), writer, result, &entities.QuarantinedItem{}); err != nil || len(writer.items) != 0 {
+		t.Errorf("Enforce of a stored fund error = %v, quarantined %d items", err, len(writer.items))
+	}
+
+	if results := service.GetResults(); len(results) != 1 || results[0] != result {
+		t.Errorf("recorded results = %v, want the fund result", results)
+	}
+
+	if _, err := NewService(&config.ValidationConfig{OnError: "DROP", OnWarning: entities.VALIDATION_ACTION_STORE}, service.log); err == nil {
+		t.Error("NewService accepted an unknown action")
+	}
+}
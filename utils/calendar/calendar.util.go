@@ -0,0 +1,182 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/datetime"
+)
+
+// Holiday struct is a day the Toronto Stock Exchange is closed on a weekday
+type Holiday struct {
+	Name string
+	Date time.Time
+}
+
+// holidayRule gets the date of a holiday in a year, false if the holiday is not observed that year
+type holidayRule struct {
+	name string
+	date func(year int) (time.Time, bool)
+}
+
+// holidayRules are the TSX holidays in calendar order, the order matters as an observed holiday takes the
+// first free weekday, e.g. Christmas on a Saturday is observed on Monday and Boxing Day on Tuesday
+var holidayRules = []holidayRule{
+	{"New Year's Day", fixed(time.January, 1)},
+	{"Family Day", since(2008, nthWeekday(time.February, time.Monday, 3))},
+	{"Good Friday", goodFriday},
+	{"Victoria Day", victoriaDay},
+	{"Canada Day", fixed(time.July, 1)},
+	{"Civic Holiday", nthWeekday(time.August, time.Monday, 1)},
+	{"Labour Day", nthWeekday(time.September, time.Monday, 1)},
+	{"Thanksgiving Day", nthWeekday(time.October, time.Monday, 2)},
+	{"Christmas Day", fixed(time.December, 25)},
+	{"Boxing Day", fixed(time.December, 26)},
+}
+
+// Holidays gets the TSX holidays of a year on the weekday they are observed
+func Holidays(year int) []*Holiday {
+	var holidays []*Holiday
+	taken := make(map[time.Time]bool)
+
+	for _, rule := range holidayRules {
+		date, ok := rule.date(year)
+		if !ok {
+			continue
+		}
+
+		// a holiday falling on a weekend or on another holiday is observed on the next free weekday
+		for isWeekend(date) || taken[date] {
+			date = date.AddDate(0, 0, 1)
+		}
+
+		taken[date] = true
+		holidays = append(holidays, &Holiday{
+			Name: rule.name,
+			Date: date,
+		})
+	}
+
+	return holidays
+}
+
+// GetHoliday gets the TSX holiday observed on a business date, nil if there is none
+func GetHoliday(t time.Time) *Holiday {
+	date := datetime.BusinessDate(t)
+	for _, holiday := range Holidays(date.Year()) {
+		if holiday.Date.Equal(date) {
+			return holiday
+		}
+	}
+
+	return nil
+}
+
+// IsHoliday checks the TSX is closed on a weekday for a holiday
+func IsHoliday(t time.Time) bool {
+	return GetHoliday(t) != nil
+}
+
+// IsBusinessDay checks the TSX is open on a business date
+func IsBusinessDay(t time.Time) bool {
+	return !isWeekend(datetime.BusinessDate(t)) && !IsHoliday(t)
+}
+
+// NextBusinessDay gets the first TSX business day after a business date
+func NextBusinessDay(t time.Time) time.Time {
+	return AddBusinessDays(t, 1)
+}
+
+// PreviousBusinessDay gets the last TSX business day before a business date
+func PreviousBusinessDay(t time.Time) time.Time {
+	return AddBusinessDays(t, -1)
+}
+
+// AddBusinessDays moves a business date by a number of TSX business days, backward if the number is negative
+func AddBusinessDays(t time.Time, days int) time.Time {
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+
+	t = datetime.BusinessDate(t)
+	for days > 0 {
+		t = t.AddDate(0, 0, step)
+		if IsBusinessDay(t) {
+			days--
+		}
+	}
+
+	return t
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// isWeekend checks a date is a Saturday or a Sunday
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// date creates a business date
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// fixed is a holiday on the same day every year
+func fixed(month time.Month, day int) func(year int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		return date(year, month, day), true
+	}
+}
+
+// nthWeekday is a holiday on the nth weekday of a month, e.g. the third Monday of February
+func nthWeekday(month time.Month, weekday time.Weekday, n int) func(year int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		first := date(year, month, 1)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+(n-1)*7), true
+	}
+}
+
+// since is a holiday observed from a year on
+func since(firstYear int, rule func(year int) (time.Time, bool)) func(year int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		if year < firstYear {
+			return time.Time{}, false
+		}
+		return rule(year)
+	}
+}
+
+// victoriaDay is the last Monday before May 25
+func victoriaDay(year int) (time.Time, bool) {
+	may24 := date(year, time.May, 24)
+	offset := (int(may24.Weekday()) - int(time.Monday) + 7) % 7
+	return may24.AddDate(0, 0, -offset), true
+}
+
+// goodFriday is the Friday before Easter Sunday
+func goodFriday(year int) (time.Time, bool) {
+	return easterSunday(year).AddDate(0, 0, -2), true
+}
+
+// easterSunday computes the Gregorian Easter Sunday with the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return date(year, time.Month(month), day)
+}
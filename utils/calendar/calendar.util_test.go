@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHolidays(t *testing.T) {
+	// TSX closures as published by the exchange
+	tests := map[int][]string{
+		2014: {"2014-01-01", "2014-02-17", "2014-04-18", "2014-05-19", "2014-07-01", "2014-08-04", "2014-09-01", "2014-10-13", "2014-12-25", "2014-12-26"},
+		2015: {"2015-01-01", "2015-02-16", "2015-04-03", "2015-05-18", "2015-07-01", "2015-08-03", "2015-09-07", "2015-10-12", "2015-12-25", "2015-12-28"},
+		2016: {"2016-01-01", "2016-02-15", "2016-03-25", "2016-05-23", "2016-07-01", "2016-08-01", "2016-09-05", "2016-10-10", "2016-12-26", "2016-12-27"},
+		2017: {"2017-01-02", "2017-02-20", "2017-04-14", "2017-05-22", "2017-07-03", "2017-08-07", "2017-09-04", "2017-10-09", "2017-12-25", "2017-12-26"},
+		2018: {"2018-01-01", "2018-02-19", "2018-03-30", "2018-05-21", "2018-07-02", "2018-08-06", "2018-09-03", "2018-10-08", "2018-12-25", "2018-12-26"},
+		2019: {"2019-01-01", "2019-02-18", "2019-04-19", "2019-05-20", "2019-07-01", "2019-08-05", "2019-09-02", "2019-10-14", "2019-12-25", "2019-12-26"},
+		2020: {"2020-01-01", "2020-02-17", "2020-04-10", "2020-05-18", "2020-07-01", "2020-08-03", "2020-09-07", "2020-10-12", "2020-12-25", "2020-12-28"},
+		2021: {"2021-01-01", "2021-02-15", "2021-04-02", "2021-05-24", "2021-07-01", "2021-08-02", "2021-09-06", "2021-10-11", "2021-12-27", "2021-12-28"},
+		2022: {"2022-01-03", "2022-02-21", "2022-04-15", "2022-05-23", "2022-07-01", "2022-08-01", "2022-09-05", "2022-10-10", "2022-12-26", "2022-12-27"},
+		2023: {"2023-01-02", "2023-02-20", "2023-04-07", "2023-05-22", "2023-07-03", "2023-08-07", "2023-09-04", "2023-10-09", "2023-12-25", "2023-12-26"},
+		2024: {"2024-01-01", "2024-02-19", "2024-03-29", "2024-05-20", "2024-07-01", "2024-08-05", "2024-09-02", "2024-10-14", "2024-12-25", "2024-12-26"},
+		2025: {"2025-01-01", "2025-02-17", "2025-04-18", "2025-05-19", "2025-07-01", "2025-08-04", "2025-09-01", "2025-10-13", "2025-12-25", "2025-12-26"},
+		2026: {"2026-01-01", "2026-02-16", "2026-04-03", "2026-05-18", "2026-07-01", "2026-08-03", "2026-09-07", "2026-10-12", "2026-12-25", "2026-12-28"},
+	}
+
+	for year, want := range tests {
+		var got []string
+		for _, holiday := range Holidays(year) {
+			got = append(got, holiday.Date.Format("2006-01-02"))
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Holidays(%d) = %v, want %v", year, got, want)
+		}
+	}
+}
+
+func TestHolidaysWithoutFamilyDay(t *testing.T) {
+	for _, holiday := range Holidays(2007) {
+		if holiday.Name == "Family Day" {
+			t.Errorf("Holidays(2007) has Family Day on %s, it is observed from 2008", holiday.Date.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestGetHoliday(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2021-12-27", "Christmas Day"},
+		{"2021-12-28", "Boxing Day"},
+		{"2020-12-28", "Boxing Day"},
+		{"2022-01-03", "New Year's Day"},
+		{"2023-07-03", "Canada Day"},
+		{"2024-03-29", "Good Friday"},
+		{"2021-09-30", ""},
+		{"2021-11-11", ""},
+	}
+
+	for _, tt := range tests {
+		holiday := GetHoliday(mustParse(t, tt.date))
+
+		var got string
+		if holiday != nil {
+			got = holiday.Name
+		}
+
+		if got != tt.want {
+			t.Errorf("GetHoliday(%s) = %q, want %q", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2021-06-25", true},
+		{"2021-06-26", false},
+		{"2021-06-27", false},
+		{"2021-12-25", false},
+		{"2021-12-27", false},
+		{"2021-12-29", true},
+		{"2022-02-21", false},
+		{"2022-09-30", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsBusinessDay(mustParse(t, tt.date)); got != tt.want {
+			t.Errorf("IsBusinessDay(%s) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestIsBusinessDayInToronto(t *testing.T) {
+	// Friday evening in Toronto is already Saturday in UTC
+	friday := time.Date(2021, time.June, 26, 1, 0, 0, 0, time.UTC)
+	if !IsBusinessDay(friday) {
+		t.Errorf("IsBusinessDay(%v) = false, want true", friday)
+	}
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2021-06-24", "2021-06-25"},
+		{"2021-06-25", "2021-06-28"},
+		{"2021-12-24", "2021-12-29"},
+		{"2020-12-24", "2020-12-29"},
+		{"2022-04-14", "2022-04-18"},
+		{"2022-12-31", "2023-01-03"},
+	}
+
+	for _, tt := range tests {
+		if got := NextBusinessDay(mustParse(t, tt.date)).Format("2006-01-02"); got != tt.want {
+			t.Errorf("NextBusinessDay(%s) = %s, want %s", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestPreviousBusinessDay(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2021-06-25", "2021-06-24"},
+		{"2021-06-28", "2021-06-25"},
+		{"2022-01-04", "2021-12-31"},
+		{"2023-05-23", "2023-05-19"},
+		{"2023-08-08", "2023-08-04"},
+	}
+
+	for _, tt := range tests {
+		if got := PreviousBusinessDay(mustParse(t, tt.date)).Format("2006-01-02"); got != tt.want {
+			t.Errorf("PreviousBusinessDay(%s) = %s, want %s", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	tests := []struct {
+		date string
+		days int
+		want string
+	}{
+		{"2021-12-23", 0, "2021-12-23"},
+		{"2021-12-23", 3, "2021-12-30"},
+		{"2021-12-30", -3, "2021-12-23"},
+		{"2024-03-25", 5, "2024-04-02"},
+	}
+
+	for _, tt := range tests {
+		if got := AddBusinessDays(mustParse(t, tt.date), tt.days).Format("2006-01-02"); got != tt.want {
+			t.Errorf("AddBusinessDays(%s, %d) = %s, want %s", tt.date, tt.days, got, tt.want)
+		}
+	}
+}
+
+func mustParse(t *testing.T, date string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		t.Fatalf("parse %s: %v", date, err)
+	}
+
+	return parsed
+}
@@ -1,20 +1,87 @@
 package datetime
 
 import (
+	"fmt"
+	"strings"
 	"time"
+
+	// embed the time zone database, the lambda runtime does not ship one
+	_ "time/tzdata"
 )
 
-// GetStarDateFromString gets start date from string
-func GetStarDateFromString(date string) (*time.Time, error) {
+// DATE_LAYOUT formats a business date
+const DATE_LAYOUT = "2006-01-02"
+
+// Toronto is the time zone of the Toronto Stock Exchange, business dates are calendar days in this zone
+var Toronto = loadLocation("America/Toronto")
+
+// dateLayouts are the date formats Vanguard uses, a layout without a zone is Toronto local time
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	DATE_LAYOUT,
+	"01/02/2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"02 Jan 2006",
+	"02-Jan-2006",
+}
+
+// ParseDate parses a date in any format Vanguard uses and normalizes it to a business date, nil if the date is empty
+func ParseDate(date string) (*time.Time, error) {
+	date = strings.TrimSpace(date)
 	if date == "" {
 		return nil, nil
 	}
 
-	startTime, err := time.Parse(time.RFC3339, date)
+	for _, layout := range dateLayouts {
+		t, err := time.ParseInLocation(layout, date, Toronto)
+		if err != nil {
+			continue
+		}
+
+		businessDate := BusinessDate(t)
+		return &businessDate, nil
+	}
+
+	return nil, fmt.Errorf("unknown date format %q", date)
+}
+
+// BusinessDate gets the Toronto calendar day of a time at midnight UTC, so it is the same day wherever it is printed.
+// A time at midnight of its own zone is a date without time of day and keeps its day.
+func BusinessDate(t time.Time) time.Time {
+	if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+		t = t.In(Toronto)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Today gets the current business date
+func Today() time.Time {
+	return BusinessDate(time.Now().In(Toronto))
+}
+
+// FormatDate formats a business date, empty if the date is nil
+func FormatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(DATE_LAYOUT)
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// loadLocation loads a time zone from the embedded database
+func loadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
 	if err != nil {
-		return nil, err
+		panic(fmt.Sprintf("load time zone %s: %v", name, err))
 	}
 
-	startTime = time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, time.UTC)
-	return &startTime, nil
+	return location
 }
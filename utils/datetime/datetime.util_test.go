@@ -0,0 +1,38 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// a time after midnight UTC is still the previous day in Toronto
+	tests := map[string]string{
+		"2021-03-25T00:00:00-04:00": "2021-03-25",
+		"2021-03-26T02:00:00Z":      "2021-03-25",
+		"2021-03-25 23:59:59":       "2021-03-25",
+		" 2021-03-25 ":              "2021-03-25",
+		"03/25/2021":                "2021-03-25",
+		"March 25, 2021":            "2021-03-25",
+		"25-Mar-2021":               "2021-03-25",
+		"":                          "",
+	}
+
+	for date, want := range tests {
+		got, err := ParseDate(date)
+		if err != nil || FormatDate(got) != want {
+			t.Errorf("ParseDate(%q) = %q, %v, want %q", date, FormatDate(got), err, want)
+			continue
+		}
+
+		if got != nil && (got.Location() != time.UTC || got.Hour() != 0) {
+			t.Errorf("ParseDate(%q) = %s, want midnight UTC", date, got)
+		}
+	}
+
+	for _, date := range []string{"25/03/2021", "N/A"} {
+		if got, err := ParseDate(date); err == nil {
+			t.Errorf("ParseDate(%q) = %s, want an error", date, FormatDate(got))
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package decimal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Decimal is an exact base 10 number for prices, amounts, fees and weights, so a distribution of 0.0725 per unit
+// stays 0.0725. It is stored as Decimal128 in mongo and written as a plain number in json. The zero value is 0.
+type Decimal struct {
+	value decimal.Decimal
+}
+
+// Zero is the decimal 0
+var Zero = Decimal{}
+
+// bsonPlaces are the decimal places a decimal is rounded to before it is stored. A product of quotients can have more
+// significant digits than the 34 of a Decimal128, 16 places like a quotient keeps any fund amount within them.
+const bsonPlaces = 16
+
+// New creates a decimal of value * 10^exp
+func New(value int64, exp int32) Decimal {
+	return Decimal{value: decimal.New(value, exp)}
+}
+
+// NewFromInt creates a decimal from an integer
+func NewFromInt(value int64) Decimal {
+	return Decimal{value: decimal.NewFromInt(value)}
+}
+
+// NewFromFloat creates a decimal from the shortest decimal representation of a float, e.g. 0.1 is 0.1
+func NewFromFloat(value float64) Decimal {
+	return Decimal{value: decimal.NewFromFloat(value)}
+}
+
+// NewFromString parses a decimal such as "0.0725", "-1.5" or "1e-3"
+func NewFromString(value string) (Decimal, error) {
+	d, err := decimal.NewFromString(strings.TrimSpace(value))
+	if err != nil {
+		return Zero, err
+	}
+
+	return Decimal{value: d}, nil
+}
+
+// RequireFromString parses a decimal and panics if it cannot, it is meant for constants
+func RequireFromString(value string) Decimal {
+	d, err := NewFromString(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// NewFromDecimal128 creates a decimal from a mongo Decimal128, e.g. a value read into an interface{}
+func NewFromDecimal128(value primitive.Decimal128) (Decimal, error) {
+	return NewFromString(value.String())
+}
+
+// Sum adds up decimals
+func Sum(values ...Decimal) Decimal {
+	sum := Zero
+	for _, value := range values {
+		sum = sum.Add(value)
+	}
+
+	return sum
+}
+
+// Max gets the greatest of two decimals
+func Max(a, b Decimal) Decimal {
+	if a.LessThan(b) {
+		return b
+	}
+
+	return a
+}
+
+// Min gets the least of two decimals
+func Min(a, b Decimal) Decimal {
+	if b.LessThan(a) {
+		return b
+	}
+
+	return a
+}
+
+// Add returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	return Decimal{value: d.value.Add(other.value)}
+}
+
+// Sub returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	return Decimal{value: d.value.Sub(other.value)}
+}
+
+// Mul returns d * other
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{value: d.value.Mul(other.value)}
+}
+
+// Div returns d / other rounded to 16 decimal places, a division by 0 returns 0 rather than panic
+// because a missing price or weight must not crash a report
+func (d Decimal) Div(other Decimal) Decimal {
+	if other.IsZero() {
+		return Zero
+	}
+
+	return Decimal{value: d.value.Div(other.value)}
+}
+
+// Percent returns d / total * 100, 0 if the total is 0
+func (d Decimal) Percent(total Decimal) Decimal {
+	return d.Mul(NewFromInt(100)).Div(total)
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: d.value.Neg()}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: d.value.Abs()}
+}
+
+// Round rounds half away from zero to the number of decimal places
+func (d Decimal) Round(places int32) Decimal {
+	return Decimal{value: d.value.Round(places)}
+}
+
+// Cmp compares d with other, it returns -1, 0 or 1
+func (d Decimal) Cmp(other Decimal) int {
+	return d.value.Cmp(other.value)
+}
+
+// Equal checks d and other are the same number, 1.50 equals 1.5
+func (d Decimal) Equal(other Decimal) bool {
+	return d.value.Equal(other.value)
+}
+
+// GreaterThan checks d > other
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.value.GreaterThan(other.value)
+}
+
+// LessThan checks d < other
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.value.LessThan(other.value)
+}
+
+// IsZero checks d is 0, it also lets bson omitempty leave out zero decimals
+func (d Decimal) IsZero() bool {
+	return d.value.IsZero()
+}
+
+// IsPositive checks d > 0
+func (d Decimal) IsPositive() bool {
+	return d.value.IsPositive()
+}
+
+// IsNegative checks d < 0
+func (d Decimal) IsNegative() bool {
+	return d.value.IsNegative()
+}
+
+// Float64 converts d to the nearest float, for math without a decimal counterpart and float only outputs
+func (d Decimal) Float64() float64 {
+	f, _ := d.value.Float64()
+	return f
+}
+
+// String formats d without exponent and trailing zeros
+func (d Decimal) String() string {
+	return d.value.String()
+}
+
+// StringFixed formats d with a fixed number of decimal places
+func (d Decimal) StringFixed(places int32) string {
+	return d.value.StringFixed(places)
+}
+
+///////////////////////////////////////////////////////////
+// Implement json and bson codecs
+///////////////////////////////////////////////////////////
+
+// MarshalJSON writes d as a json number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads a json number or a quoted number, null and "" are 0
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+		return nil
+	}
+
+	raw := strings.Trim(string(data), `"`)
+	if strings.TrimSpace(raw) == "" {
+		*d = Zero
+		return nil
+	}
+
+	parsed, err := NewFromString(raw)
+	if err != nil {
+		return fmt.Errorf("decimal: cannot unmarshal %s: %v", data, err)
+	}
+
+	*d = parsed
+	return nil
+}
+
+// MarshalBSONValue writes d rounded to 16 decimal places as a Decimal128
+func (d Decimal) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	rounded := d.Round(bsonPlaces)
+
+	value, err := primitive.ParseDecimal128(rounded.String())
+	if err != nil {
+		return 0, nil, fmt.Errorf("decimal: cannot marshal %s: %v", rounded.String(), err)
+	}
+
+	return bsontype.Decimal128, bsoncore.AppendDecimal128(nil, value), nil
+}
+
+// UnmarshalBSONValue reads a Decimal128. Doubles, integers and strings are read too so documents stored
+// before decimals were introduced still decode, null is 0.
+func (d *Decimal) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := bsoncore.Value{Type: t, Data: data}
+
+	var err error
+	switch t {
+	case bsontype.Decimal128:
+		*d, err = NewFromDecimal128(value.Decimal128())
+	case bsontype.Double:
+		*d = NewFromFloat(value.Double())
+	case bsontype.Int32:
+		*d = NewFromInt(int64(value.Int32()))
+	case bsontype.Int64:
+		*d = NewFromInt(value.Int64())
+	case bsontype.String:
+		if raw := value.StringValue(); strings.TrimSpace(raw) == "" {
+			*d = Zero
+		} else {
+			*d, err = NewFromString(raw)
+		}
+	case bsontype.Null, bsontype.Undefined:
+		*d = Zero
+	default:
+		err = fmt.Errorf("decimal: cannot unmarshal bson %s", t)
+	}
+
+	return err
+}
+
+///////////////////////////////////////////////////////////
+// Implement nullable decimal
+///////////////////////////////////////////////////////////
+
+// NullDecimal is a decimal which may be absent, so a 0% fee is stored as 0 and a missing one is not stored.
+// It is omitted by bson omitempty only when absent, and json null or "" is absent.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+// NewNullDecimal creates a present decimal
+func NewNullDecimal(d Decimal) NullDecimal {
+	return NullDecimal{Decimal: d, Valid: true}
+}
+
+// Ptr returns the decimal, nil if absent
+func (n NullDecimal) Ptr() *Decimal {
+	if !n.Valid {
+		return nil
+	}
+
+	d := n.Decimal
+	return &d
+}
+
+// IsZero checks n is absent, a present 0 is not zero
+func (n NullDecimal) IsZero() bool {
+	return !n.Valid
+}
+
+// MarshalJSON writes n as a json number, null if absent
+func (n NullDecimal) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.Decimal.MarshalJSON()
+}
+
+// UnmarshalJSON reads a json number or a quoted number, null and "" are absent
+func (n *NullDecimal) UnmarshalJSON(data []byte) error {
+	raw := strings.TrimSpace(strings.Trim(string(bytes.TrimSpace(data)), `"`))
+	if raw == "" || raw == "null" {
+		*n = NullDecimal{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Decimal.UnmarshalJSON(data)
+}
+
+// MarshalBSONValue writes n as a Decimal128, null if absent
+func (n NullDecimal) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if !n.Valid {
+		return bsontype.Null, nil, nil
+	}
+
+	return n.Decimal.MarshalBSONValue()
+}
+
+// UnmarshalBSONValue reads a decimal like Decimal does, null is absent
+func (n *NullDecimal) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bsontype.Null || t == bsontype.Undefined {
+		*n = NullDecimal{}
+		return nil
+	}
+
+	n.Valid = true
+	return n.Decimal.UnmarshalBSONValue(t, data)
+}
@@ -0,0 +1,124 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type amount struct {
+	Amount Decimal `json:"amount" bson:"amount"`
+}
+
+type optionalAmount struct {
+	Amount Decimal `bson:"amount,omitempty"`
+}
+
+func TestJSON(t *testing.T) {
+	got, err := json.Marshal(amount{Amount: RequireFromString("0.0725")})
+	if err != nil || string(got) != `{"amount":0.0725}` {
+		t.Errorf("json.Marshal = %s, %v, want a plain number", got, err)
+	}
+
+	tests := map[string]string{
+		`{"amount":0.0725}`:   "0.0725",
+		`{"amount":" 1.25 "}`: "1.25",
+		`{"amount":1e-3}`:     "0.001",
+		`{"amount":null}`:     "0",
+		`{"amount":""}`:       "0",
+	}
+
+	for data, want := range tests {
+		var got amount
+		if err := json.Unmarshal([]byte(data), &got); err != nil || !got.Amount.Equal(RequireFromString(want)) {
+			t.Errorf("json.Unmarshal(%s) = %s, %v, want %s", data, got.Amount, err, want)
+		}
+	}
+
+	var invalid amount
+	if err := json.Unmarshal([]byte(`{"amount":"n/a"}`), &invalid); err == nil {
+		t.Error("json.Unmarshal accepted n/a")
+	}
+}
+
+func TestBSON(t *testing.T) {
+	data, err := bson.Marshal(amount{Amount: RequireFromString("0.0725")})
+	if err != nil {
+		t.Fatalf("bson.Marshal failed: %v", err)
+	}
+
+	if value := bson.Raw(data).Lookup("amount"); value.Type != bsontype.Decimal128 || value.Decimal128().String() != "0.0725" {
+		t.Errorf("bson.Marshal stored %s %v, want Decimal128 0.0725", value.Type, value)
+	}
+
+	// a product of quotients has more digits than a Decimal128 holds
+	product := NewFromInt(10).Div(NewFromInt(3)).Mul(NewFromInt(100000).Div(NewFromInt(7)))
+	data, err = bson.Marshal(amount{Amount: product})
+	if err != nil {
+		t.Fatalf("bson.Marshal of %s failed: %v", product, err)
+	}
+
+	if value := bson.Raw(data).Lookup("amount"); value.Decimal128().String() != product.Round(16).String() {
+		t.Errorf("bson.Marshal stored %v, want %s", value, product.Round(16))
+	}
+
+	// omitempty leaves out a zero decimal only
+	for value, want := range map[string]bool{"0": false, "0.00": false, "0.01": true} {
+		data, _ := bson.Marshal(optionalAmount{Amount: RequireFromString(value)})
+		if _, err := bson.Raw(data).LookupErr("amount"); (err == nil) != want {
+			t.Errorf("bson.Marshal with omitempty of %s stored it: %v, want %v", value, err == nil, want)
+		}
+	}
+
+	d128, _ := primitive.ParseDecimal128("0.0725")
+
+	// documents written before schema version 2 hold doubles, integers or scraped strings
+	tests := []struct {
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{d128, "0.0725", false},
+		{0.0725, "0.0725", false},
+		{int32(12), "12", false},
+		{int64(1234567890123), "1234567890123", false},
+		{" ", "0", false},
+		{nil, "0", false},
+		{"n/a", "", true},
+		{true, "", true},
+	}
+
+	for _, test := range tests {
+		data, _ := bson.Marshal(bson.D{{Key: "amount", Value: test.value}})
+
+		var got amount
+		err := bson.Unmarshal(data, &got)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("bson.Unmarshal(%v) = %s, want an error", test.value, got.Amount)
+			}
+			continue
+		}
+
+		if err != nil || !got.Amount.Equal(RequireFromString(test.want)) {
+			t.Errorf("bson.Unmarshal(%v) = %s, %v, want %s", test.value, got.Amount, err, test.want)
+		}
+	}
+}
+
+func TestDivByZero(t *testing.T) {
+	if got := RequireFromString("0.0725").Div(RequireFromString("0.29")); !got.Equal(RequireFromString("0.25")) {
+		t.Errorf("0.0725 / 0.29 = %s, want 0.25", got)
+	}
+
+	if got := NewFromInt(1).Div(Zero); !got.IsZero() {
+		t.Errorf("1 / 0 = %s, want 0", got)
+	}
+
+	if got := NewFromInt(5).Percent(Zero); !got.IsZero() {
+		t.Errorf("5 percent of 0 = %s, want 0", got)
+	}
+}
@@ -0,0 +1,165 @@
+package identifier
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cusipCountries are the ISIN country prefixes whose national code is the CUSIP
+var cusipCountries = map[string]bool{
+	"CA": true,
+	"US": true,
+}
+
+// sedolWeights are the weights of the first six characters of a SEDOL
+var sedolWeights = []int{1, 3, 1, 7, 3, 9}
+
+// Normalize removes whitespace and hyphens from an identifier and upper cases it
+func Normalize(identifier string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, identifier)
+}
+
+// IsValidIsin checks an ISIN is two letters, nine alphanumerics and a Luhn check digit computed over
+// the code where letters are replaced by two digits (A is 10, Z is 35)
+func IsValidIsin(isin string) bool {
+	isin = Normalize(isin)
+	if len(isin) != 12 {
+		return false
+	}
+
+	var digits []int
+	for i, c := range isin {
+		switch {
+		case c >= 'A' && c <= 'Z' && i < 11:
+			n := charValue(c)
+			digits = append(digits, n/10, n%10)
+		case c >= '0' && c <= '9' && i >= 2:
+			digits = append(digits, charValue(c))
+		default:
+			return false
+		}
+	}
+
+	// double every other digit starting from the rightmost one before the check digit
+	var sum int
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if (len(digits)-1-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// IsValidSedol checks a SEDOL is six alphanumerics without vowels and a check digit of their weighted sum
+func IsValidSedol(sedol string) bool {
+	sedol = Normalize(sedol)
+	if len(sedol) != 7 {
+		return false
+	}
+
+	var sum int
+	for i, c := range sedol[:6] {
+		if !isAlphanumeric(c) || strings.ContainsRune("AEIOU", c) {
+			return false
+		}
+		sum += charValue(c) * sedolWeights[i]
+	}
+
+	check := rune(sedol[6])
+	if check < '0' || check > '9' {
+		return false
+	}
+
+	return int(check-'0') == (10-sum%10)%10
+}
+
+// IsValidCusip checks a CUSIP is eight alphanumerics and a check digit where every second value is doubled
+func IsValidCusip(cusip string) bool {
+	cusip = Normalize(cusip)
+	if len(cusip) != 9 {
+		return false
+	}
+
+	var sum int
+	for i, c := range cusip[:8] {
+		var v int
+		switch {
+		case isAlphanumeric(c):
+			v = charValue(c)
+		case c == '*':
+			v = 36
+		case c == '@':
+			v = 37
+		case c == '#':
+			v = 38
+		default:
+			return false
+		}
+
+		if i%2 == 1 {
+			v *= 2
+		}
+		sum += v/10 + v%10
+	}
+
+	check := rune(cusip[8])
+	if check < '0' || check > '9' {
+		return false
+	}
+
+	return int(check-'0') == (10-sum%10)%10
+}
+
+// GetIsinCountry gets the ISO 3166 country prefix of an ISIN, empty if the ISIN is invalid
+func GetIsinCountry(isin string) string {
+	isin = Normalize(isin)
+	if !IsValidIsin(isin) {
+		return ""
+	}
+
+	return isin[:2]
+}
+
+// GetCusipFromIsin gets the CUSIP embedded in a Canadian or U.S. ISIN, empty if the ISIN has none
+func GetCusipFromIsin(isin string) string {
+	isin = Normalize(isin)
+	if !cusipCountries[GetIsinCountry(isin)] {
+		return ""
+	}
+
+	cusip := isin[2:11]
+	if !IsValidCusip(cusip) {
+		return ""
+	}
+
+	return cusip
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// isAlphanumeric checks a character is an upper case letter or a digit
+func isAlphanumeric(c rune) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// charValue gets the value of a digit, or of a letter where A is 10 and Z is 35
+func charValue(c rune) int {
+	if c >= '0' && c <= '9' {
+		return int(c - '0')
+	}
+
+	return int(c-'A') + 10
+}
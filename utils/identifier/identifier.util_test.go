@@ -0,0 +1,48 @@
+package identifier
+
+import "testing"
+
+func TestCheckDigits(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid func(string) bool
+		value string
+		want  bool
+	}{
+		{"isin", IsValidIsin, "CA7800871021", true},
+		{"isin", IsValidIsin, "US 0378 3310 05", true},
+		{"isin", IsValidIsin, "US0378331006", false},
+		{"isin", IsValidIsin, "US03783310X5", false},
+		{"sedol", IsValidSedol, "b0ybkj7", true},
+		{"sedol", IsValidSedol, "0263495", false},
+		{"sedol", IsValidSedol, "A0YBKJ7", false},
+		{"cusip", IsValidCusip, "037833100", true},
+		{"cusip", IsValidCusip, "037833101", false},
+		{"cusip", IsValidCusip, "", false},
+	}
+
+	for _, test := range tests {
+		if got := test.valid(test.value); got != test.want {
+			t.Errorf("valid %s %q = %v, want %v", test.name, test.value, got, test.want)
+		}
+	}
+}
+
+func TestGetCusipFromIsin(t *testing.T) {
+	// only north american ISINs embed a CUSIP
+	tests := map[string]string{
+		"CA7800871021": "780087102",
+		"GB0002634946": "",
+		"US0378331006": "",
+	}
+
+	for isin, want := range tests {
+		if got := GetCusipFromIsin(isin); got != want {
+			t.Errorf("GetCusipFromIsin(%q) = %q, want %q", isin, got, want)
+		}
+	}
+
+	if got := GetIsinCountry("gb0002634946"); got != "GB" {
+		t.Errorf("GetIsinCountry(gb0002634946) = %q, want GB", got)
+	}
+}
@@ -0,0 +1,349 @@
+package lookup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/consts"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Country struct
+type Country struct {
+	Name       string
+	Alpha2Code string
+	Alpha3Code string
+	NumberCode int
+	Latitude   float64
+	Longitude  float64
+	Region     string
+	Market     string
+}
+
+// countryAliases are common names missing from consts.Countries keyed by the alpha-3 code they stand for
+var countryAliases = map[string][]string{
+	"USA": {"USA", "US", "U.S.", "United States of America", "America"},
+	"GBR": {"UK", "U.K.", "Great Britain", "Britain", "England"},
+	"PRK": {"North Korea"},
+	"CZE": {"Czechia"},
+	"TUR": {"Türkiye"},
+	"HKG": {"Hong Kong SAR"},
+	"MAC": {"Macau", "Macao SAR"},
+	"LAO": {"Laos"},
+	"SYR": {"Syria"},
+	"MKD": {"North Macedonia", "Macedonia"},
+	"SWZ": {"Eswatini"},
+	"CPV": {"Cabo Verde"},
+	"VAT": {"Vatican", "Vatican City"},
+	"PSE": {"Palestine"},
+	"ARE": {"UAE"},
+	"NLD": {"Holland"},
+}
+
+// countryMatchThreshold is the lowest similarity a misspelled country name is fuzzy matched at, above 1 never matches
+var countryMatchThreshold = 0.8
+
+// stopWords are dropped from normalized names, e.g. the Netherlands is Netherlands
+var stopWords = map[string]bool{
+	"the": true,
+}
+
+// wordAliases are replaced in normalized names, e.g. St. Lucia is Saint Lucia
+var wordAliases = map[string]string{
+	"st": "saint",
+}
+
+// lookup tables built once from consts.Countries
+var (
+	countriesByName   = make(map[string]*Country)
+	countriesByAlpha2 = make(map[string]*Country)
+	countriesByAlpha3 = make(map[string]*Country)
+	countriesByNumber = make(map[int]*Country)
+)
+
+func init() {
+	var derived []string
+	var derivedCountries []*Country
+	var headNames []string
+	heads := make(map[string][]*Country)
+
+	for _, c := range consts.Countries {
+		country := &Country{
+			Name:       c.Name,
+			Alpha2Code: c.Alpha2Code,
+			Alpha3Code: c.Alpha3Code,
+			NumberCode: c.NumberCode,
+			Latitude:   c.Latitude,
+			Longitude:  c.Longitude,
+			Region:     getRegion(c.Alpha3Code, c.Latitude, c.Longitude),
+			Market:     getMarket(c.Alpha3Code),
+		}
+
+		// the first country of a code is its canonical one, the others are alternative names
+		if _, ok := countriesByAlpha3[country.Alpha3Code]; !ok {
+			countriesByAlpha2[country.Alpha2Code] = country
+			countriesByAlpha3[country.Alpha3Code] = country
+			countriesByNumber[country.NumberCode] = country
+		}
+
+		addCountryName(country.Name, country)
+
+		// "Iran, Islamic Republic of" is also "Islamic Republic of Iran" and "Iran"
+		if i := strings.Index(country.Name, ","); i >= 0 {
+			head, tail := strings.TrimSpace(country.Name[:i]), strings.TrimSpace(country.Name[i+1:])
+			derived = append(derived, tail+" "+head)
+			derivedCountries = append(derivedCountries, country)
+			if _, ok := heads[head]; !ok {
+				headNames = append(headNames, head)
+			}
+			heads[head] = append(heads[head], country)
+		}
+	}
+
+	// a head shared by several countries, e.g. Virgin Islands, is ambiguous
+	for _, head := range headNames {
+		if countries := heads[head]; len(countries) == 1 {
+			derived = append(derived, head)
+			derivedCountries = append(derivedCountries, countries[0])
+		}
+	}
+
+	if err := addCountryAliases(countryAliases); err != nil {
+		panic(err)
+	}
+
+	// derived names never override a listed name or alias, e.g. Korea stays the Republic of Korea
+	for i, name := range derived {
+		addCountryName(name, derivedCountries[i])
+	}
+}
+
+// NormalizeName normalizes a name for lookups: accents, punctuation, case and stop words are dropped,
+// & is and, e.g. "Côte d'Ivoire" is "cote d ivoire" and "Oil & Gas" is "oil and gas"
+func NormalizeName(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	name, _, err := transform.String(t, name)
+	if err != nil {
+		return ""
+	}
+
+	name = strings.ReplaceAll(strings.ToLower(name), "&", " and ")
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var kept []string
+	for _, word := range words {
+		if stopWords[word] {
+			continue
+		}
+
+		if alias, ok := wordAliases[word]; ok {
+			word = alias
+		}
+
+		kept = append(kept, word)
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// FindCountry finds a country by name, alias, alpha-2, alpha-3 or numeric code, nil if there is no such country
+func FindCountry(nameOrCode string) *Country {
+	nameOrCode = strings.TrimSpace(nameOrCode)
+
+	if country, ok := countriesByName[NormalizeName(nameOrCode)]; ok {
+		return country
+	}
+
+	code := strings.ToUpper(nameOrCode)
+	if country, ok := countriesByAlpha3[code]; ok {
+		return country
+	}
+
+	if country, ok := countriesByAlpha2[code]; ok {
+		return country
+	}
+
+	if number, err := strconv.Atoi(nameOrCode); err == nil {
+		return countriesByNumber[number]
+	}
+
+	return nil
+}
+
+// ResolveCountry finds a country like FindCountry and falls back to the most similar known name, e.g. "Phillipines"
+// is the Philippines. It returns the confidence of the match from 0 to 1, 1 for an exact match, and nil if no name
+// is similar enough or the most similar names are of different countries.
+func ResolveCountry(name string) (*Country, float64) {
+	if country := FindCountry(name); country != nil {
+		return country, 1
+	}
+
+	key := NormalizeName(name)
+	if key == "" {
+		return nil, 0
+	}
+
+	var best *Country
+	var bestSimilarity float64
+	for candidate, country := range countriesByName {
+		score := similarity(key, candidate)
+		switch {
+		case score > bestSimilarity:
+			best, bestSimilarity = country, score
+		case score == bestSimilarity && best != nil && best.Alpha3Code != country.Alpha3Code:
+			best = nil
+		}
+	}
+
+	if best == nil || bestSimilarity < countryMatchThreshold {
+		return nil, bestSimilarity
+	}
+
+	return best, bestSimilarity
+}
+
+// SetCountryMatchThreshold sets the lowest similarity from 0 to 1 ResolveCountry fuzzy matches a name at,
+// it is not safe to call while names are being resolved
+func SetCountryMatchThreshold(threshold float64) {
+	countryMatchThreshold = threshold
+}
+
+// LoadCountryAliases adds the country names of a json file keyed by alpha-3 code, e.g. {"KOR": ["South Korea"]},
+// a name already known keeps its country. It is not safe to call while names are being resolved.
+func LoadCountryAliases(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var aliases map[string][]string
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return fmt.Errorf("parse country aliases %s: %w", path, err)
+	}
+
+	return addCountryAliases(aliases)
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// getRegion gets the region of a country from its latitude and longitude unless consts.CountryRegions says otherwise
+func getRegion(alpha3Code string, latitude, longitude float64) string {
+	if region, ok := consts.CountryRegions[alpha3Code]; ok {
+		return region
+	}
+
+	switch {
+	// Pacific islands east of the date line
+	case longitude < -115 && !consts.NorthAmericaCountries[alpha3Code]:
+		return consts.REGION_ASIA_PACIFIC
+	case longitude < -25 && consts.NorthAmericaCountries[alpha3Code]:
+		return consts.REGION_NORTH_AMERICA
+	case longitude < -25:
+		return consts.REGION_LATIN_AMERICA
+	case longitude >= 60:
+		return consts.REGION_ASIA_PACIFIC
+	case latitude >= 35:
+		return consts.REGION_EUROPE
+	// east of the Suez canal and north of the Gulf of Aden
+	case longitude >= 34 && latitude >= 12:
+		return consts.REGION_MIDDLE_EAST
+	default:
+		return consts.REGION_AFRICA
+	}
+}
+
+// getMarket gets the market classification of a country
+func getMarket(alpha3Code string) string {
+	if market, ok := consts.MarketClassifications[alpha3Code]; ok {
+		return market
+	}
+
+	return consts.MARKET_UNCLASSIFIED
+}
+
+// addCountryAliases indexes the names of countries keyed by alpha-3 code
+func addCountryAliases(aliases map[string][]string) error {
+	for code := range aliases {
+		if _, ok := countriesByAlpha3[strings.ToUpper(code)]; !ok {
+			return fmt.Errorf("country alias of unknown code %s", code)
+		}
+	}
+
+	for code, names := range aliases {
+		country := countriesByAlpha3[strings.ToUpper(code)]
+		for _, name := range names {
+			addCountryName(name, country)
+		}
+	}
+
+	return nil
+}
+
+// addCountryName indexes a country by a name unless the name is taken
+func addCountryName(name string, country *Country) {
+	key := NormalizeName(name)
+	if key == "" {
+		return
+	}
+
+	if _, ok := countriesByName[key]; !ok {
+		countriesByName[key] = country
+	}
+}
+
+// similarity is 1 minus the levenshtein distance of two names relative to the longer one, 1 for the same name
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	if longest == 0 {
+		return 1
+	}
+
+	// distances of the prefixes of a to the previous and current prefix of b
+	previous := make([]int, len(ra)+1)
+	current := make([]int, len(ra)+1)
+	for i := range previous {
+		previous[i] = i
+	}
+
+	for j := 1; j <= len(rb); j++ {
+		current[0] = j
+		for i := 1; i <= len(ra); i++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[i] = minInt(previous[i]+1, current[i-1]+1, previous[i-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(ra)])/float64(longest)
+}
+
+// minInt gets the smallest of some ints
+func minInt(first int, others ...int) int {
+	for _, other := range others {
+		if other < first {
+			first = other
+		}
+	}
+
+	return first
+}
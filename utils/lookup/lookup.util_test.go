@@ -0,0 +1,110 @@
+package lookup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"  United   States ": "united states",
+		"Côte d'Ivoire":      "cote d ivoire",
+		"The Netherlands":    "netherlands",
+		"St. Lucia":          "saint lucia",
+		"Oil & Gas":          "oil and gas",
+	}
+
+	for name, want := range tests {
+		if got := NormalizeName(name); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFindCountry(t *testing.T) {
+	// names, aliases and the three kinds of ISO codes find the same country, an ambiguous name none
+	tests := []struct {
+		nameOrCode string
+		want       string
+	}{
+		{"canada", "CAN"},
+		{"CA", "CAN"},
+		{"124", "CAN"},
+		{"Holland", "NLD"},
+		{"U.S.", "USA"},
+		{"Virgin Islands", ""},
+	}
+
+	for _, test := range tests {
+		var got string
+		if country := FindCountry(test.nameOrCode); country != nil {
+			got = country.Alpha3Code
+		}
+
+		if got != test.want {
+			t.Errorf("FindCountry(%q) = %q, want %q", test.nameOrCode, got, test.want)
+		}
+	}
+}
+
+func TestResolveCountry(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		want      string
+	}{
+		{"Canada", 1.1, "CAN"},
+		{"Germny", 0.85, "DEU"},
+		{"Germny", 0.9, ""},
+		{"Phillipines", 0.8, "PHL"},
+		{"Atlantis", 0.8, ""},
+	}
+
+	defer SetCountryMatchThreshold(countryMatchThreshold)
+
+	for _, test := range tests {
+		SetCountryMatchThreshold(test.threshold)
+
+		var got string
+		country, confidence := ResolveCountry(test.name)
+		if country != nil {
+			got = country.Alpha3Code
+		}
+
+		// an exact match has full confidence whatever the threshold
+		if got != test.want || (country != nil && confidence != 1 && confidence < test.threshold) {
+			t.Errorf("ResolveCountry(%q) at %v = %q with confidence %v, want %q", test.name, test.threshold, got, confidence, test.want)
+		}
+	}
+}
+
+func TestLoadCountryAliases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lookup")
+	if err != nil {
+		t.Fatalf("create temp dir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "aliases.json")
+	if err := ioutil.WriteFile(path, []byte(`{"kor": ["Republic of South Korea"]}`), 0644); err != nil {
+		t.Fatalf("write aliases failed: %v", err)
+	}
+
+	if err := LoadCountryAliases(path); err != nil {
+		t.Fatalf("LoadCountryAliases error = %v", err)
+	}
+
+	if country := FindCountry("Republic of South Korea"); country == nil || country.Alpha3Code != "KOR" {
+		t.Errorf("FindCountry of the alias = %v, want KOR", country)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"XXX": ["Nowhere"]}`), 0644); err != nil {
+		t.Fatalf("write aliases failed: %v", err)
+	}
+
+	if err := LoadCountryAliases(path); err == nil {
+		t.Error("LoadCountryAliases accepted an unknown country code")
+	}
+}
@@ -0,0 +1,27 @@
+package runid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// runIDKey is the context key of the run id
+type runIDKey struct{}
+
+// New generates a run id identifying one scrape run
+func New() string {
+	id, _ := uuid.NewRandom()
+	return id.String()
+}
+
+// NewContext returns a copy of the context carrying the run id
+func NewContext(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// FromContext gets the run id of the context, it is empty outside a scrape run
+func FromContext(ctx context.Context) string {
+	runID, _ := ctx.Value(runIDKey{}).(string)
+	return runID
+}
@@ -0,0 +1,352 @@
+package taxonomy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lenoobz/aws-vanguard-ca-etf-scraper/utils/lookup"
+)
+
+// Taxonomy versions, named after the year their GICS structure took effect
+const (
+	GICS_2016 = "GICS_2016"
+	GICS_2018 = "GICS_2018"
+	GICS_2023 = "GICS_2023"
+)
+
+// Version struct is a sector taxonomy in effect from a date until the next version
+type Version struct {
+	Name          string
+	EffectiveDate time.Time
+	Sectors       []*Sector
+}
+
+// Sector struct is a sector of a taxonomy version, its three letter code is the same in every version
+type Sector struct {
+	Code           string
+	GicsCode       string
+	Name           string
+	IndustryGroups []*IndustryGroup
+}
+
+// IndustryGroup struct is an industry group of a sector keyed by its GICS code
+type IndustryGroup struct {
+	Code   string
+	Name   string
+	Sector *Sector
+}
+
+// Classification struct is a sector, and an industry group if known, of a taxonomy version
+type Classification struct {
+	Version       string
+	Sector        *Sector
+	IndustryGroup *IndustryGroup
+}
+
+// versionDef struct defines a taxonomy version
+type versionDef struct {
+	name      string
+	effective time.Time
+	sectors   []sectorDef
+	moves     map[string]string // industry groups of the previous version moved to another code, e.g. Media
+}
+
+// sectorDef struct defines a sector and its industry groups as GICS code and name pairs
+type sectorDef struct {
+	code     string
+	gicsCode string
+	name     string
+	groups   [][2]string
+}
+
+// versionDefs are the taxonomy versions in effective date order
+var versionDefs = []versionDef{
+	{
+		// Real Estate split from Financials
+		name:      GICS_2016,
+		effective: time.Date(2016, time.September, 1, 0, 0, 0, 0, time.UTC),
+		sectors: []sectorDef{
+			{"ENG", "10", "Energy", [][2]string{{"1010", "Energy"}}},
+			{"MTL", "15", "Materials", [][2]string{{"1510", "Materials"}}},
+			{"IND", "20", "Industrials", [][2]string{{"2010", "Capital Goods"}, {"2020", "Commercial & Professional Services"}, {"2030", "Transportation"}}},
+			{"CND", "25", "Consumer Discretionary", [][2]string{{"2510", "Automobiles & Components"}, {"2520", "Consumer Durables & Apparel"}, {"2530", "Consumer Services"}, {"2540", "Media"}, {"2550", "Retailing"}}},
+			{"CNS", "30", "Consumer Staples", [][2]string{{"3010", "Food & Staples Retailing"}, {"3020", "Food, Beverage & Tobacco"}, {"3030", "Household & Personal Products"}}},
+			{"HEC", "35", "Health Care", [][2]string{{"3510", "Health Care Equipment & Services"}, {"3520", "Pharmaceuticals, Biotechnology & Life Sciences"}}},
+			{"FIN", "40", "Financials", [][2]string{{"4010", "Banks"}, {"4020", "Diversified Financials"}, {"4030", "Insurance"}}},
+			{"TEC", "45", "Information Technology", [][2]string{{"4510", "Software & Services"}, {"4520", "Technology Hardware & Equipment"}, {"4530", "Semiconductors & Semiconductor Equipment"}}},
+			{"TEL", "50", "Telecommunication Services", [][2]string{{"5010", "Telecommunication Services"}}},
+			{"UTL", "55", "Utilities", [][2]string{{"5510", "Utilities"}}},
+			{"RLE", "60", "Real Estate", [][2]string{{"6010", "Real Estate"}}},
+		},
+	},
+	{
+		// Telecommunication Services became Communication Services and took Media from Consumer Discretionary
+		name:      GICS_2018,
+		effective: time.Date(2018, time.October, 1, 0, 0, 0, 0, time.UTC),
+		sectors: []sectorDef{
+			{"ENG", "10", "Energy", [][2]string{{"1010", "Energy"}}},
+			{"MTL", "15", "Materials", [][2]string{{"1510", "Materials"}}},
+			{"IND", "20", "Industrials", [][2]string{{"2010", "Capital Goods"}, {"2020", "Commercial & Professional Services"}, {"2030", "Transportation"}}},
+			{"CND", "25", "Consumer Discretionary", [][2]string{{"2510", "Automobiles & Components"}, {"2520", "Consumer Durables & Apparel"}, {"2530", "Consumer Services"}, {"2550", "Retailing"}}},
+			{"CNS", "30", "Consumer Staples", [][2]string{{"3010", "Food & Staples Retailing"}, {"3020", "Food, Beverage & Tobacco"}, {"3030", "Household & Personal Products"}}},
+			{"HEC", "35", "Health Care", [][2]string{{"3510", "Health Care Equipment & Services"}, {"3520", "Pharmaceuticals, Biotechnology & Life Sciences"}}},
+			{"FIN", "40", "Financials", [][2]string{{"4010", "Banks"}, {"4020", "Diversified Financials"}, {"4030", "Insurance"}}},
+			{"TEC", "45", "Information Technology", [][2]string{{"4510", "Software & Services"}, {"4520", "Technology Hardware & Equipment"}, {"4530", "Semiconductors & Semiconductor Equipment"}}},
+			{"TEL", "50", "Communication Services", [][2]string{{"5010", "Telecommunication Services"}, {"5020", "Media & Entertainment"}}},
+			{"UTL", "55", "Utilities", [][2]string{{"5510", "Utilities"}}},
+			{"RLE", "60", "Real Estate", [][2]string{{"6010", "Real Estate"}}},
+		},
+		moves: map[string]string{
+			"2540": "5020",
+		},
+	},
+	{
+		// retail groups renamed, Diversified Financials became Financial Services and Real Estate split in two
+		name:      GICS_2023,
+		effective: time.Date(2023, time.March, 20, 0, 0, 0, 0, time.UTC),
+		sectors: []sectorDef{
+			{"ENG", "10", "Energy", [][2]string{{"1010", "Energy"}}},
+			{"MTL", "15", "Materials", [][2]string{{"1510", "Materials"}}},
+			{"IND", "20", "Industrials", [][2]string{{"2010", "Capital Goods"}, {"2020", "Commercial & Professional Services"}, {"2030", "Transportation"}}},
+			{"CND", "25", "Consumer Discretionary", [][2]string{{"2510", "Automobiles & Components"}, {"2520", "Consumer Durables & Apparel"}, {"2530", "Consumer Services"}, {"2550", "Consumer Discretionary Distribution & Retail"}}},
+			{"CNS", "30", "Consumer Staples", [][2]string{{"3010", "Consumer Staples Distribution & Retail"}, {"3020", "Food, Beverage & Tobacco"}, {"3030", "Household & Personal Products"}}},
+			{"HEC", "35", "Health Care", [][2]string{{"3510", "Health Care Equipment & Services"}, {"3520", "Pharmaceuticals, Biotechnology & Life Sciences"}}},
+			{"FIN", "40", "Financials", [][2]string{{"4010", "Banks"}, {"4020", "Financial Services"}, {"4030", "Insurance"}}},
+			{"TEC", "45", "Information Technology", [][2]string{{"4510", "Software & Services"}, {"4520", "Technology Hardware & Equipment"}, {"4530", "Semiconductors & Semiconductor Equipment"}}},
+			{"TEL", "50", "Communication Services", [][2]string{{"5010", "Telecommunication Services"}, {"5020", "Media & Entertainment"}}},
+			{"UTL", "55", "Utilities", [][2]string{{"5510", "Utilities"}}},
+			{"RLE", "60", "Real Estate", [][2]string{{"6010", "Equity Real Estate Investment Trusts (REITs)"}, {"6020", "Real Estate Management & Development"}}},
+		},
+	},
+}
+
+// sectorLabels are the Russell and ICB sector names Vanguard has used keyed to the sector they stand for in every version
+var sectorLabels = map[string]string{
+	"Producer Durables":      "IND",
+	"Financial Services":     "FIN",
+	"Consumer Goods":         "CNS",
+	"Consumer Services":      "CND",
+	"Technology":             "TEC",
+	"Basic Materials":        "MTL",
+	"Materials & Processing": "MTL",
+	"Oil & Gas":              "ENG",
+	"Telecommunications":     "TEL",
+}
+
+// index struct holds the lookup tables of a taxonomy version
+type index struct {
+	version        *Version
+	moves          map[string]string
+	sectorsByCode  map[string]*Sector
+	sectorsByLabel map[string]*Sector
+	groupsByCode   map[string]*IndustryGroup
+	groupsByLabel  map[string]*IndustryGroup
+	previous, next *index
+}
+
+// lookup tables built once from versionDefs, in effective date order
+var indexes []*index
+var indexesByName = make(map[string]*index)
+
+func init() {
+	for _, def := range versionDefs {
+		idx := newIndex(def)
+		if len(indexes) > 0 {
+			idx.previous = indexes[len(indexes)-1]
+			idx.previous.next = idx
+		}
+
+		indexes = append(indexes, idx)
+		indexesByName[def.name] = idx
+	}
+
+	// sector names of every version are labels of every version, e.g. Telecommunication Services is TEL
+	for _, idx := range indexes {
+		for _, other := range indexes {
+			for _, sector := range other.version.Sectors {
+				idx.addSectorLabel(sector.Name, sector.Code)
+			}
+		}
+
+		for label, code := range sectorLabels {
+			idx.addSectorLabel(label, code)
+		}
+	}
+}
+
+// Versions gets the taxonomy versions in effective date order
+func Versions() []*Version {
+	var versions []*Version
+	for _, idx := range indexes {
+		versions = append(versions, idx.version)
+	}
+
+	return versions
+}
+
+// GetVersion gets a taxonomy version by name, nil if there is no such version
+func GetVersion(name string) *Version {
+	if idx, ok := indexesByName[name]; ok {
+		return idx.version
+	}
+
+	return nil
+}
+
+// CurrentVersion gets the name of the latest taxonomy version
+func CurrentVersion() string {
+	return indexes[len(indexes)-1].version.Name
+}
+
+// VersionAt gets the name of the taxonomy version in effect on a date, the first version before it took effect
+func VersionAt(t time.Time) string {
+	name := indexes[0].version.Name
+	for _, idx := range indexes {
+		if t.Before(idx.version.EffectiveDate) {
+			break
+		}
+		name = idx.version.Name
+	}
+
+	return name
+}
+
+// Classify classifies a sector or industry group label under a taxonomy version, nil if the label or the version is unknown
+func Classify(label, version string) *Classification {
+	idx, ok := indexesByName[version]
+	if !ok {
+		return nil
+	}
+
+	key := lookup.NormalizeName(label)
+	if sector, ok := idx.sectorsByLabel[key]; ok {
+		return &Classification{Version: version, Sector: sector}
+	}
+
+	if group, ok := idx.groupsByLabel[key]; ok {
+		return &Classification{Version: version, Sector: group.Sector, IndustryGroup: group}
+	}
+
+	return nil
+}
+
+// Find finds the classification of a sector code and an optional industry group code under a taxonomy version,
+// nil if they are unknown
+func Find(version, sectorCode, industryGroupCode string) *Classification {
+	idx, ok := indexesByName[version]
+	if !ok {
+		return nil
+	}
+
+	if group, ok := idx.groupsByCode[industryGroupCode]; ok {
+		return &Classification{Version: version, Sector: group.Sector, IndustryGroup: group}
+	}
+
+	if sector, ok := idx.sectorsByCode[sectorCode]; ok {
+		return &Classification{Version: version, Sector: sector}
+	}
+
+	return nil
+}
+
+// Remap remaps a classification to another taxonomy version, one version at a time. An industry group moved
+// to another sector follows it, e.g. Media of Consumer Discretionary in GICS_2016 is Media & Entertainment
+// of Communication Services in GICS_2018. A sector without its industry group keeps its code.
+func Remap(classification *Classification, version string) (*Classification, error) {
+	idx, ok := indexesByName[classification.Version]
+	if !ok {
+		return nil, fmt.Errorf("unknown taxonomy version %s", classification.Version)
+	}
+
+	target, ok := indexesByName[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown taxonomy version %s", version)
+	}
+
+	sectorCode := classification.Sector.Code
+	var groupCode string
+	if classification.IndustryGroup != nil {
+		groupCode = classification.IndustryGroup.Code
+	}
+
+	for idx != target {
+		if idx.version.EffectiveDate.Before(target.version.EffectiveDate) {
+			idx = idx.next
+			if moved, ok := idx.moves[groupCode]; ok {
+				groupCode = moved
+			}
+		} else {
+			for from, to := range idx.moves {
+				if to == groupCode {
+					groupCode = from
+					break
+				}
+			}
+			idx = idx.previous
+		}
+	}
+
+	remapped := Find(version, sectorCode, groupCode)
+	if remapped == nil {
+		return nil, fmt.Errorf("sector %s is not in taxonomy version %s", sectorCode, version)
+	}
+
+	return remapped, nil
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// newIndex builds the lookup tables of a taxonomy version but its sector labels
+func newIndex(def versionDef) *index {
+	idx := &index{
+		version: &Version{
+			Name:          def.name,
+			EffectiveDate: def.effective,
+		},
+		moves:          def.moves,
+		sectorsByCode:  make(map[string]*Sector),
+		sectorsByLabel: make(map[string]*Sector),
+		groupsByCode:   make(map[string]*IndustryGroup),
+		groupsByLabel:  make(map[string]*IndustryGroup),
+	}
+
+	for _, s := range def.sectors {
+		sector := &Sector{
+			Code:     s.code,
+			GicsCode: s.gicsCode,
+			Name:     s.name,
+		}
+
+		for _, g := range s.groups {
+			group := &IndustryGroup{
+				Code:   g[0],
+				Name:   g[1],
+				Sector: sector,
+			}
+
+			sector.IndustryGroups = append(sector.IndustryGroups, group)
+			idx.groupsByCode[group.Code] = group
+			idx.groupsByLabel[lookup.NormalizeName(group.Name)] = group
+		}
+
+		idx.version.Sectors = append(idx.version.Sectors, sector)
+		idx.sectorsByCode[sector.Code] = sector
+	}
+
+	return idx
+}
+
+// addSectorLabel indexes a sector by a label unless the label is taken
+func (idx *index) addSectorLabel(label, code string) {
+	sector, ok := idx.sectorsByCode[code]
+	if !ok {
+		panic(fmt.Sprintf("sector label %s of unknown code %s", label, code))
+	}
+
+	key := lookup.NormalizeName(label)
+	if _, ok := idx.sectorsByLabel[key]; !ok {
+		idx.sectorsByLabel[key] = sector
+	}
+}
@@ -0,0 +1,43 @@
+package taxonomy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVersionAt(t *testing.T) {
+	tests := map[string]string{
+		"2010-01-04": GICS_2016,
+		"2018-09-28": GICS_2016,
+		"2018-10-01": GICS_2018,
+		"2023-03-20": GICS_2023,
+	}
+
+	for date, want := range tests {
+		at, _ := time.Parse("2006-01-02", date)
+		if got := VersionAt(at); got != want {
+			t.Errorf("VersionAt(%s) = %s, want %s", date, got, want)
+		}
+	}
+}
+
+func TestClassifyAndRemap(t *testing.T) {
+	// media moved from consumer discretionary to communication services in 2018
+	media := Classify("Media", GICS_2016)
+	if media == nil || media.Sector.Code != "CND" || media.IndustryGroup == nil || media.IndustryGroup.Code != "2540" {
+		t.Fatalf("Classify(Media, %s) = %+v, want CND 2540", GICS_2016, media)
+	}
+
+	remapped, err := Remap(media, GICS_2023)
+	if err != nil || remapped.Sector.Code != "TEL" || remapped.IndustryGroup.Code != "5020" {
+		t.Errorf("Remap of media to %s = %+v, %v, want TEL 5020", GICS_2023, remapped, err)
+	}
+
+	if got := Classify("Space Tourism", GICS_2023); got != nil {
+		t.Errorf("Classify of an unknown label = %+v, want nil", got)
+	}
+
+	if _, err := Remap(Find(GICS_2023, "ENG", ""), "GICS_1999"); err == nil {
+		t.Error("Remap to an unknown version succeeded")
+	}
+}
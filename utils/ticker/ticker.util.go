@@ -1,8 +1,118 @@
 package ticker
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
-// GenYahooTickerFromVanguardTicker gen yahoo ticker from vanguard ticker
+// TSX vendor affixes
+const (
+	yahooSuffix     = ".TO"
+	refinitivSuffix = ".TO"
+	googlePrefix    = "TSE:"
+	bloombergSuffix = " CN"
+)
+
+// GenYahooTickerFromVanguardTicker gen yahoo ticker from vanguard ticker, e.g. VFV becomes VFV.TO and the U.S. dollar
+// share class VBG.U becomes VBG-U.TO
 func GenYahooTickerFromVanguardTicker(vanguardTicker string) string {
-	return fmt.Sprintf("%s.TO", vanguardTicker)
+	base, class := splitShareClass(vanguardTicker)
+	if class == "" {
+		return base + yahooSuffix
+	}
+
+	return fmt.Sprintf("%s-%s%s", base, class, yahooSuffix)
+}
+
+// GenGoogleTicker gen google ticker from exchange ticker, e.g. TSE:VFV or TSE:VBG.U
+func GenGoogleTicker(exchangeTicker string) string {
+	return googlePrefix + strings.ToUpper(exchangeTicker)
+}
+
+// GenBloombergTicker gen bloomberg ticker from exchange ticker, e.g. VFV CN or VBG/U CN
+func GenBloombergTicker(exchangeTicker string) string {
+	base, class := splitShareClass(exchangeTicker)
+	if class == "" {
+		return base + bloombergSuffix
+	}
+
+	return fmt.Sprintf("%s/%s%s", base, class, bloombergSuffix)
+}
+
+// GenRefinitivTicker gen refinitiv ticker from exchange ticker, e.g. VFV.TO or VBGu.TO
+func GenRefinitivTicker(exchangeTicker string) string {
+	base, class := splitShareClass(exchangeTicker)
+	return base + strings.ToLower(class) + refinitivSuffix
+}
+
+// ParseExchangeTicker gets the exchange ticker, e.g. VBG.U, from a ticker of any vendor format
+func ParseExchangeTicker(ticker string) string {
+	ticker = strings.TrimSpace(ticker)
+	if base, class, ok := splitRefinitivTicker(ticker); ok {
+		return base + "." + class
+	}
+
+	ticker = strings.ToUpper(ticker)
+	switch {
+	case strings.HasPrefix(ticker, googlePrefix):
+		return strings.TrimPrefix(ticker, googlePrefix)
+	case strings.HasSuffix(ticker, bloombergSuffix):
+		return strings.Replace(strings.TrimSuffix(ticker, bloombergSuffix), "/", ".", 1)
+	case strings.HasSuffix(ticker, yahooSuffix):
+		return strings.Replace(strings.TrimSuffix(ticker, yahooSuffix), "-", ".", 1)
+	default:
+		return ticker
+	}
+}
+
+// NormalizeYahooTicker normalizes user input such as vfv, VFV.TO, TSE:VFV or VFV CN to yahoo ticker
+func NormalizeYahooTicker(ticker string) string {
+	return GenYahooTickerFromVanguardTicker(ParseExchangeTicker(ticker))
+}
+
+// SplitYahooTicker splits a yahoo ticker into the exchange ticker and the exchange suffix, e.g. VBG-U.TO becomes VBG.U
+// and .TO, VFV.NE becomes VFV and .NE
+func SplitYahooTicker(yahooTicker string) (string, string) {
+	yahooTicker = strings.ToUpper(strings.TrimSpace(yahooTicker))
+
+	var suffix string
+	if i := strings.LastIndex(yahooTicker, "."); i > 0 {
+		yahooTicker, suffix = yahooTicker[:i], yahooTicker[i:]
+	}
+
+	return strings.Replace(yahooTicker, "-", ".", 1), suffix
+}
+
+///////////////////////////////////////////////////////////
+// Implement helper function
+///////////////////////////////////////////////////////////
+
+// splitShareClass splits an exchange ticker into its base and its share class suffix, e.g. VBG.U becomes VBG and U
+func splitShareClass(exchangeTicker string) (string, string) {
+	exchangeTicker = strings.ToUpper(strings.TrimSpace(exchangeTicker))
+
+	if i := strings.IndexAny(exchangeTicker, ".-"); i >= 0 {
+		return exchangeTicker[:i], exchangeTicker[i+1:]
+	}
+
+	return exchangeTicker, ""
+}
+
+// splitRefinitivTicker splits a refinitiv ticker with a lower case share class, e.g. VBGu.TO becomes VBG and U
+func splitRefinitivTicker(ticker string) (string, string, bool) {
+	if !strings.HasSuffix(strings.ToUpper(ticker), refinitivSuffix) {
+		return "", "", false
+	}
+
+	body := ticker[:len(ticker)-len(refinitivSuffix)]
+	if len(body) < 2 {
+		return "", "", false
+	}
+
+	base, class := body[:len(body)-1], body[len(body)-1:]
+	if class != strings.ToLower(class) || class == strings.ToUpper(class) || base != strings.ToUpper(base) || base == strings.ToLower(base) {
+		return "", "", false
+	}
+
+	return base, strings.ToUpper(class), true
 }
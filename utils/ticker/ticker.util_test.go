@@ -0,0 +1,49 @@
+package ticker
+
+import "testing"
+
+func TestParseExchangeTicker(t *testing.T) {
+	tests := map[string]string{
+		" vfv ":     "VFV",
+		"VBG.U":     "VBG.U",
+		"VBG-U.TO":  "VBG.U",
+		"VBG.U.TO":  "VBG.U",
+		"tse:vbg.u": "VBG.U",
+		"vbg/u cn":  "VBG.U",
+	}
+
+	for vendorTicker, want := range tests {
+		if got := ParseExchangeTicker(vendorTicker); got != want {
+			t.Errorf("ParseExchangeTicker(%q) = %q, want %q", vendorTicker, got, want)
+		}
+	}
+
+	if got := NormalizeYahooTicker("VBG.U.TO"); got != "VBG-U.TO" {
+		t.Errorf("NormalizeYahooTicker(VBG.U.TO) = %q, want VBG-U.TO", got)
+	}
+
+	for yahooTicker, want := range map[string][2]string{"VBG-U.TO": {"VBG.U", ".TO"}, "vfv.ne": {"VFV", ".NE"}, "VCN": {"VCN", ""}} {
+		if exchangeTicker, suffix := SplitYahooTicker(yahooTicker); exchangeTicker != want[0] || suffix != want[1] {
+			t.Errorf("SplitYahooTicker(%q) = %q, %q, want %q, %q", yahooTicker, exchangeTicker, suffix, want[0], want[1])
+		}
+	}
+}
+
+func TestVendorTickerRoundTrip(t *testing.T) {
+	for _, exchangeTicker := range []string{"VFV", "VBG.U"} {
+		for _, vendorTicker := range []string{
+			GenYahooTickerFromVanguardTicker(exchangeTicker),
+			GenGoogleTicker(exchangeTicker),
+			GenBloombergTicker(exchangeTicker),
+			GenRefinitivTicker(exchangeTicker),
+		} {
+			if got := ParseExchangeTicker(vendorTicker); got != exchangeTicker {
+				t.Errorf("ParseExchangeTicker(%q) = %q, want %q", vendorTicker, got, exchangeTicker)
+			}
+		}
+	}
+
+	if got := GenRefinitivTicker("VBG.U"); got != "VBGu.TO" {
+		t.Errorf("GenRefinitivTicker(VBG.U) = %q, want VBGu.TO", got)
+	}
+}